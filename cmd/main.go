@@ -50,11 +50,15 @@ func run(ctx context.Context) error {
 	repo := repository.NewRepository(db, redisClient)
 	queue := redisrepo.NewWebhookQueue(redisClient.Client())
 
-	worker := worker.NewWebhookWorker(redisClient.Client(), cfg.WebhookURL)
+	webhookWorker := worker.NewWebhookWorker(redisClient.Client(), cfg.WebhookURL)
 	workerCtx, workerCancel := context.WithCancel(ctx)
-	go worker.Run(workerCtx)
+	go webhookWorker.Run(workerCtx)
+
+	srv := services.NewService(repo, cfg, queue)
+
+	scheduler := worker.NewIncidentScheduler(srv.IncidentScheduler, cfg.ScheduleInterval)
+	go scheduler.Run(workerCtx)
 
-	srv := services.NewService(repo, cfg.WindowMin, queue)
 	handler := handlers.NewHandler(srv)
 
 	server := &http.Server{
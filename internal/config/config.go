@@ -3,15 +3,28 @@ package config
 import (
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
+	"time"
 )
 
 type Config struct {
-	Port           string      `env:"PORT" env-default:":8080"`
-	DBPath         string      `env:"DB_PATH" env-required:"true"`
-	OperatorAPIKey string      `env:"OPERATOR_API_KEY" env-required:"true"`
-	Redis          RedisConfig `yaml:"redis"`
-	WebhookURL     string      `env:"WEBHOOK_URL" env-required:"true"`
-	WindowMin      int         `env:"STATS_TIME_WINDOW_MINUTES" env-required:"true"`
+	Port             string        `env:"PORT" env-default:":8080"`
+	DBPath           string        `env:"DB_PATH" env-required:"true"`
+	OperatorAPIKey   string        `env:"OPERATOR_API_KEY" env-required:"true"`
+	Redis            RedisConfig   `yaml:"redis"`
+	WebhookURL       string        `env:"WEBHOOK_URL" env-required:"true"`
+	WindowMin        int           `env:"STATS_TIME_WINDOW_MINUTES" env-required:"true"`
+	ScheduleInterval time.Duration `env:"INCIDENT_SCHEDULE_INTERVAL" env-default:"15s"`
+	PolicyPath       string        `env:"NOTIFICATION_POLICY_PATH"`
+	PurgeRetention   time.Duration `env:"INCIDENT_PURGE_RETENTION" env-default:"720h"`
+	// Инцидент того же типа, созданный не раньше DuplicateWindow назад и
+	// перекрывающий зону нового не меньше чем на DuplicateOverlap, считается дублем
+	DuplicateWindow  time.Duration `env:"DUPLICATE_WINDOW" env-default:"30m"`
+	DuplicateOverlap float64       `env:"DUPLICATE_OVERLAP" env-default:"0.5"`
+	IdempotencyTTL   time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// TrackHorizon ограничивает экстраполяцию позиции по скорости; 0 — отключить
+	TrackHorizon time.Duration `env:"TRACK_EXTRAPOLATION_HORIZON" env-default:"10m"`
+
+	NotificationPolicy NotificationPolicy
 }
 
 func GetConfig() (*Config, error) {
@@ -21,5 +34,10 @@ func GetConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to connect DB: %w", err)
 	}
 
+	cfg.NotificationPolicy, err = LoadNotificationPolicy(cfg.PolicyPath)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// SeverityPolicy определяет, как реагировать на инцидент данной важности:
+// считать ли его опасностью, оповещать ли пользователя, как часто повторять
+// оповещение об одном и том же инциденте (0 — при каждой проверке) и с каким
+// приоритетом доставлять вебхук.
+type SeverityPolicy struct {
+	Danger   bool          `yaml:"danger"`
+	Notify   bool          `yaml:"notify"`
+	Renotify time.Duration `yaml:"renotify"`
+	Priority string        `yaml:"priority"`
+}
+
+type NotificationPolicy struct {
+	Severities map[models.Severity]SeverityPolicy `yaml:"severities"`
+}
+
+func DefaultNotificationPolicy() NotificationPolicy {
+	return NotificationPolicy{
+		Severities: map[models.Severity]SeverityPolicy{
+			models.SeverityInfo: {
+				Danger: false,
+				Notify: false,
+			},
+			models.SeverityWarning: {
+				Danger:   false,
+				Notify:   true,
+				Renotify: time.Hour,
+				Priority: models.PriorityLow,
+			},
+			models.SeverityDanger: {
+				Danger:   true,
+				Notify:   true,
+				Renotify: 15 * time.Minute,
+				Priority: models.PriorityNormal,
+			},
+			models.SeverityCritical: {
+				Danger:   true,
+				Notify:   true,
+				Renotify: 0,
+				Priority: models.PriorityHigh,
+			},
+		},
+	}
+}
+
+// For возвращает политику для важности; не описанные в файле уровни
+// берутся из политики по умолчанию.
+func (p NotificationPolicy) For(severity models.Severity) SeverityPolicy {
+	if policy, ok := p.Severities[severity.OrDefault()]; ok {
+		return policy
+	}
+	return DefaultNotificationPolicy().Severities[severity.OrDefault()]
+}
+
+func LoadNotificationPolicy(path string) (NotificationPolicy, error) {
+	policy := DefaultNotificationPolicy()
+	if path == "" {
+		return policy, nil
+	}
+
+	var fromFile NotificationPolicy
+	if err := cleanenv.ReadConfig(path, &fromFile); err != nil {
+		return NotificationPolicy{}, fmt.Errorf("failed to read notification policy: %w", err)
+	}
+
+	for severity, sp := range fromFile.Severities {
+		if !severity.Valid() {
+			return NotificationPolicy{}, fmt.Errorf("unknown severity %q in notification policy", severity)
+		}
+		switch sp.Priority {
+		case "", models.PriorityHigh, models.PriorityNormal, models.PriorityLow:
+		default:
+			return NotificationPolicy{}, fmt.Errorf("unknown priority %q for severity %q", sp.Priority, severity)
+		}
+		policy.Severities[severity] = sp
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNotificationPolicy(t *testing.T) {
+	testTable := []struct {
+		name        string
+		yaml        string
+		expected    func(p NotificationPolicy)
+		expectedErr string
+	}{
+		{
+			name: "Overrides one severity",
+			yaml: "severities:\n  warning:\n    danger: true\n    notify: true\n    renotify: 30m\n    priority: high\n",
+			expected: func(p NotificationPolicy) {
+				assert.Equal(t, SeverityPolicy{Danger: true, Notify: true, Renotify: 30 * time.Minute, Priority: models.PriorityHigh}, p.For(models.SeverityWarning))
+				assert.Equal(t, DefaultNotificationPolicy().Severities[models.SeverityDanger], p.For(models.SeverityDanger))
+			},
+		},
+		{
+			name: "Empty file keeps defaults",
+			yaml: "severities: {}\n",
+			expected: func(p NotificationPolicy) {
+				assert.Equal(t, DefaultNotificationPolicy(), p)
+			},
+		},
+		{
+			name:        "Unknown severity",
+			yaml:        "severities:\n  fatal:\n    notify: true\n",
+			expectedErr: `unknown severity "fatal" in notification policy`,
+		},
+		{
+			name:        "Unknown priority",
+			yaml:        "severities:\n  danger:\n    notify: true\n    priority: urgent\n",
+			expectedErr: `unknown priority "urgent" for severity "danger"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(testCase.yaml), 0o600))
+
+			policy, err := LoadNotificationPolicy(path)
+
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			testCase.expected(policy)
+		})
+	}
+}
+
+func TestLoadNotificationPolicy_Defaults(t *testing.T) {
+	policy, err := LoadNotificationPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultNotificationPolicy(), policy)
+
+	// файл в репозитории описывает ту же политику, что и значения по умолчанию
+	shipped, err := LoadNotificationPolicy("notification_policy.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultNotificationPolicy(), shipped)
+
+	_, err = LoadNotificationPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestNotificationPolicy_For(t *testing.T) {
+	policy := NotificationPolicy{Severities: map[models.Severity]SeverityPolicy{
+		models.SeverityInfo: {Notify: true},
+	}}
+
+	assert.Equal(t, SeverityPolicy{Notify: true}, policy.For(models.SeverityInfo))
+	// не описанная важность берётся из политики по умолчанию
+	assert.Equal(t, DefaultNotificationPolicy().Severities[models.SeverityCritical], policy.For(models.SeverityCritical))
+	// пустая важность считается важностью по умолчанию
+	assert.Equal(t, policy.For(models.DefaultSeverity), policy.For(""))
+}
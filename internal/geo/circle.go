@@ -0,0 +1,32 @@
+package geo
+
+import "math"
+
+const earthRadiusMeters = 6371008.8
+
+// Circle аппроксимирует круг радиусом radiusMeters вокруг точки замкнутым
+// кольцом из segments отрезков. Нужен форматам без понятия круга (KML).
+func Circle(lat, lon, radiusMeters float64, segments int) Ring {
+	latRad := lat * math.Pi / 180
+	lonRad := lon * math.Pi / 180
+	angular := radiusMeters / earthRadiusMeters
+
+	ring := make(Ring, 0, segments+1)
+	for i := 0; i < segments; i++ {
+		bearing := 2 * math.Pi * float64(i) / float64(segments)
+
+		pLat := math.Asin(math.Sin(latRad)*math.Cos(angular) +
+			math.Cos(latRad)*math.Sin(angular)*math.Cos(bearing))
+		pLon := lonRad + math.Atan2(
+			math.Sin(bearing)*math.Sin(angular)*math.Cos(latRad),
+			math.Cos(angular)-math.Sin(latRad)*math.Sin(pLat),
+		)
+
+		ring = append(ring, Position{
+			math.Mod(pLon*180/math.Pi+540, 360) - 180,
+			pLat * 180 / math.Pi,
+		})
+	}
+
+	return append(ring, ring[0])
+}
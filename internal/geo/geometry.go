@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+// Position — пара координат GeoJSON в порядке [lon, lat].
+type Position [2]float64
+
+type Ring []Position
+
+type Polygon []Ring
+
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParseZone разбирает GeoJSON-геометрию зоны инцидента (Polygon или MultiPolygon)
+// и проверяет её корректность: замкнутость колец, диапазоны координат и
+// отсутствие самопересечений.
+func ParseZone(data []byte) ([]Polygon, error) {
+	var g Geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, invalid("geometry must be a GeoJSON object")
+	}
+
+	var polygons []Polygon
+
+	switch g.Type {
+	case TypePolygon:
+		var p Polygon
+		if err := json.Unmarshal(g.Coordinates, &p); err != nil {
+			return nil, invalid("polygon coordinates must be an array of linear rings")
+		}
+		polygons = []Polygon{p}
+	case TypeMultiPolygon:
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil {
+			return nil, invalid("multipolygon coordinates must be an array of polygons")
+		}
+		if len(polygons) == 0 {
+			return nil, invalid("multipolygon must contain at least one polygon")
+		}
+	default:
+		return nil, invalid("unsupported geometry type %q, expected Polygon or MultiPolygon", g.Type)
+	}
+
+	for i, p := range polygons {
+		if err := validatePolygon(p); err != nil {
+			if g.Type == TypeMultiPolygon {
+				return nil, invalid("polygon %d: %v", i, err)
+			}
+			return nil, invalid("%v", err)
+		}
+	}
+
+	return polygons, nil
+}
+
+func validatePolygon(p Polygon) error {
+	if len(p) == 0 {
+		return errors.New("polygon must contain at least one ring")
+	}
+
+	for i, ring := range p {
+		if err := validateRing(ring); err != nil {
+			return fmt.Errorf("ring %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRing(ring Ring) error {
+	if len(ring) < 4 {
+		return errors.New("linear ring must contain at least 4 positions")
+	}
+
+	for _, pos := range ring {
+		if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+			return fmt.Errorf("position [%g, %g] is out of range", pos[0], pos[1])
+		}
+	}
+
+	if ring[0] != ring[len(ring)-1] {
+		return errors.New("linear ring is not closed: first and last positions differ")
+	}
+
+	if selfIntersects(ring) {
+		return errors.New("linear ring is self-intersecting")
+	}
+
+	return nil
+}
+
+// selfIntersects проверяет попарно все несмежные рёбра кольца.
+// Для операторских полигонов (десятки-сотни вершин) квадратичной
+// сложности достаточно.
+func selfIntersects(ring Ring) bool {
+	n := len(ring) - 1 // количество рёбер
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			adjacent := j == i+1 || (i == 0 && j == n-1)
+			if adjacent {
+				if collinearOverlap(ring[i], ring[i+1], ring[j], ring[j+1]) {
+					return true
+				}
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func orientation(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func onSegment(a, b, p Position) bool {
+	return min(a[0], b[0]) <= p[0] && p[0] <= max(a[0], b[0]) &&
+		min(a[1], b[1]) <= p[1] && p[1] <= max(a[1], b[1])
+}
+
+func segmentsIntersect(p1, p2, q1, q2 Position) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	switch {
+	case d1 == 0 && onSegment(q1, q2, p1):
+		return true
+	case d2 == 0 && onSegment(q1, q2, p2):
+		return true
+	case d3 == 0 && onSegment(p1, p2, q1):
+		return true
+	case d4 == 0 && onSegment(p1, p2, q2):
+		return true
+	}
+
+	return false
+}
+
+// collinearOverlap ловит «шипы»: соседние рёбра, которые возвращаются
+// по той же линии и перекрываются не только в общей вершине.
+func collinearOverlap(p1, p2, q1, q2 Position) bool {
+	if orientation(p1, p2, q1) != 0 || orientation(p1, p2, q2) != 0 {
+		return false
+	}
+
+	shared := map[Position]bool{}
+	for _, a := range []Position{p1, p2} {
+		for _, b := range []Position{q1, q2} {
+			if a == b {
+				shared[a] = true
+			}
+		}
+	}
+
+	for _, p := range []Position{p1, p2} {
+		if !shared[p] && onSegment(q1, q2, p) {
+			return true
+		}
+	}
+	for _, q := range []Position{q1, q2} {
+		if !shared[q] && onSegment(p1, p2, q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidGeometry, fmt.Sprintf(format, args...))
+}
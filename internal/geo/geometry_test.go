@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseZone(t *testing.T) {
+	testTable := []struct {
+		name        string
+		input       string
+		polygons    int
+		expectedErr bool
+	}{
+		{
+			name:     "Polygon",
+			input:    `{"type":"Polygon","coordinates":[[[37.6,55.7],[37.7,55.7],[37.7,55.8],[37.6,55.8],[37.6,55.7]]]}`,
+			polygons: 1,
+		},
+		{
+			name: "Polygon with hole",
+			input: `{"type":"Polygon","coordinates":[
+				[[0,0],[10,0],[10,10],[0,10],[0,0]],
+				[[2,2],[4,2],[4,4],[2,4],[2,2]]
+			]}`,
+			polygons: 1,
+		},
+		{
+			name: "MultiPolygon",
+			input: `{"type":"MultiPolygon","coordinates":[
+				[[[0,0],[1,0],[1,1],[0,0]]],
+				[[[5,5],[6,5],[6,6],[5,5]]]
+			]}`,
+			polygons: 2,
+		},
+		{
+			name:        "Unclosed ring",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Too few positions",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Self-intersecting bow tie",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[1,1],[1,0],[0,1],[0,0]]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Spike",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[2,0],[1,0],[1,1],[0,0]]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Out of range",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[200,0],[1,1],[0,0]]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Unsupported type",
+			input:       `{"type":"Point","coordinates":[0,0]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Empty multipolygon",
+			input:       `{"type":"MultiPolygon","coordinates":[]}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			polygons, err := ParseZone([]byte(testCase.input))
+
+			if testCase.expectedErr {
+				assert.True(t, errors.Is(err, ErrInvalidGeometry))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, polygons, testCase.polygons)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	TypeLineString      = "LineString"
+	TypeMultiLineString = "MultiLineString"
+)
+
+// Line — линия из последовательных позиций: участок дороги или путей.
+type Line []Position
+
+// IsLine сообщает, задаёт ли GeoJSON-геометрия линию (LineString или
+// MultiLineString). Корректность координат не проверяется.
+func IsLine(data []byte) bool {
+	var g Geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return false
+	}
+	return g.Type == TypeLineString || g.Type == TypeMultiLineString
+}
+
+// ParseLine разбирает GeoJSON-геометрию LineString или MultiLineString
+// и проверяет диапазоны координат и число различных позиций в каждой линии.
+func ParseLine(data []byte) ([]Line, error) {
+	var g Geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, invalid("geometry must be a GeoJSON object")
+	}
+
+	var lines []Line
+
+	switch g.Type {
+	case TypeLineString:
+		var l Line
+		if err := json.Unmarshal(g.Coordinates, &l); err != nil {
+			return nil, invalid("linestring coordinates must be an array of positions")
+		}
+		lines = []Line{l}
+	case TypeMultiLineString:
+		if err := json.Unmarshal(g.Coordinates, &lines); err != nil {
+			return nil, invalid("multilinestring coordinates must be an array of linestrings")
+		}
+		if len(lines) == 0 {
+			return nil, invalid("multilinestring must contain at least one linestring")
+		}
+	default:
+		return nil, invalid("unsupported geometry type %q, expected LineString or MultiLineString", g.Type)
+	}
+
+	for i, l := range lines {
+		if err := validateLine(l); err != nil {
+			if g.Type == TypeMultiLineString {
+				return nil, invalid("linestring %d: %v", i, err)
+			}
+			return nil, invalid("%v", err)
+		}
+	}
+
+	return lines, nil
+}
+
+func validateLine(l Line) error {
+	if len(l) < 2 {
+		return errors.New("linestring must contain at least 2 positions")
+	}
+
+	distinct := false
+	for _, pos := range l {
+		if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+			return fmt.Errorf("position [%g, %g] is out of range", pos[0], pos[1])
+		}
+		if pos != l[0] {
+			distinct = true
+		}
+	}
+
+	if !distinct {
+		return errors.New("linestring must contain at least 2 distinct positions")
+	}
+
+	return nil
+}
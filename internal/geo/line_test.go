@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLine(t *testing.T) {
+	testTable := []struct {
+		name        string
+		input       string
+		lines       int
+		expectedErr bool
+	}{
+		{
+			name:  "LineString",
+			input: `{"type":"LineString","coordinates":[[37.6,55.7],[37.7,55.75],[37.8,55.7]]}`,
+			lines: 1,
+		},
+		{
+			name:  "MultiLineString",
+			input: `{"type":"MultiLineString","coordinates":[[[0,0],[1,1]],[[2,2],[3,3]]]}`,
+			lines: 2,
+		},
+		{
+			name:        "Single position",
+			input:       `{"type":"LineString","coordinates":[[0,0]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Repeated position",
+			input:       `{"type":"LineString","coordinates":[[1,1],[1,1]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Out of range",
+			input:       `{"type":"LineString","coordinates":[[0,0],[0,95]]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Polygon",
+			input:       `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			lines, err := ParseLine([]byte(testCase.input))
+
+			if testCase.expectedErr {
+				assert.True(t, errors.Is(err, ErrInvalidGeometry))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, lines, testCase.lines)
+		})
+	}
+}
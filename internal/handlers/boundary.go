@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/incidentio"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// ListBoundaries отдаёт границы без геометрии; level и parent_id сужают выборку.
+func (h *Handler) ListBoundaries(w http.ResponseWriter, r *http.Request) {
+	var filter models.BoundaryFilter
+
+	if v := r.URL.Query().Get("level"); v != "" {
+		filter.Level = models.BoundaryLevel(v)
+		if !filter.Level.Valid() {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "level must be one of region, city, district")
+			return
+		}
+	}
+	if v := r.URL.Query().Get("parent_id"); v != "" {
+		parentID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "parent_id must be an integer")
+			return
+		}
+		filter.ParentID = &parentID
+	}
+
+	boundaries, err := h.services.ListBoundaries(r.Context(), filter)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить границы")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(boundaries)
+}
+
+func (h *Handler) GetBoundary(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	b, err := h.services.GetBoundary(r.Context(), id)
+	if err != nil {
+		writeBoundaryError(w, err, "Не удалось получить границу")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(b)
+}
+
+// ImportBoundaries принимает GeoJSON FeatureCollection с properties code,
+// name, level и parent_code.
+func (h *Handler) ImportBoundaries(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusRequestEntityTooLarge, "Файл импорта слишком большой")
+		return
+	}
+
+	boundaries, err := incidentio.ParseBoundaries(data)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.services.ImportBoundaries(r.Context(), boundaries)
+	if err != nil {
+		writeBoundaryError(w, err, "Не удалось импортировать границы")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *Handler) DeleteBoundary(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.services.DeleteBoundary(r.Context(), id); err != nil {
+		writeBoundaryError(w, err, "Не удалось удалить границу")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeBoundaryError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		common.WriteErrorResponse(w, http.StatusNotFound, "Граница не найдена")
+	case errors.Is(err, models.ErrBoundaryExists):
+		common.WriteErrorResponse(w, http.StatusConflict, "Граница с таким кодом уже загружена")
+	case errors.Is(err, models.ErrBoundaryInUse):
+		common.WriteErrorResponse(w, http.StatusConflict, "Граница используется инцидентами или вложенными границами")
+	default:
+		common.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
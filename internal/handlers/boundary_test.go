@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_Boundaries(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockBoundary)
+
+	parentID := int64(1)
+
+	testTable := []struct {
+		name               string
+		method             string
+		path               string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:   "List by level and parent",
+			method: http.MethodGet,
+			path:   "/api/v1/boundaries/?level=district&parent_id=1",
+			mockBehavior: func(s *mock_service.MockBoundary) {
+				s.EXPECT().ListBoundaries(gomock.Any(), models.BoundaryFilter{
+					Level:    models.BoundaryDistrict,
+					ParentID: &parentID,
+				}).Return([]models.Boundary{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "List unknown level",
+			method:             http.MethodGet,
+			path:               "/api/v1/boundaries/?level=street",
+			mockBehavior:       func(s *mock_service.MockBoundary) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Import",
+			method: http.MethodPost,
+			path:   "/api/v1/boundaries/import",
+			body: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"code": "msk", "name": "Москва", "level": "city"},
+				 "geometry": {"type": "Polygon", "coordinates": [[[37.3, 55.5], [37.9, 55.5], [37.9, 55.9], [37.3, 55.5]]]}},
+				{"type": "Feature", "properties": {"code": "msk-cao", "name": "ЦАО", "level": "district", "parent_code": "msk"},
+				 "geometry": {"type": "Polygon", "coordinates": [[[37.5, 55.7], [37.7, 55.7], [37.7, 55.8], [37.5, 55.7]]]}}
+			]}`,
+			mockBehavior: func(s *mock_service.MockBoundary) {
+				s.EXPECT().ImportBoundaries(gomock.Any(), gomock.Len(2)).
+					Return(models.BoundaryImportResult{Imported: 2, IDs: []int64{1, 2}}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "Import without level",
+			method: http.MethodPost,
+			path:   "/api/v1/boundaries/import",
+			body: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"code": "msk", "name": "Москва"},
+				 "geometry": {"type": "Polygon", "coordinates": [[[37.3, 55.5], [37.9, 55.5], [37.9, 55.9], [37.3, 55.5]]]}}
+			]}`,
+			mockBehavior:       func(s *mock_service.MockBoundary) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Import existing code",
+			method: http.MethodPost,
+			path:   "/api/v1/boundaries/import",
+			body: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"code": "msk", "name": "Москва", "level": "city"},
+				 "geometry": {"type": "Polygon", "coordinates": [[[37.3, 55.5], [37.9, 55.5], [37.9, 55.9], [37.3, 55.5]]]}}
+			]}`,
+			mockBehavior: func(s *mock_service.MockBoundary) {
+				s.EXPECT().ImportBoundaries(gomock.Any(), gomock.Any()).
+					Return(models.BoundaryImportResult{}, models.ErrBoundaryExists)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Get unknown",
+			method: http.MethodGet,
+			path:   "/api/v1/boundaries/42",
+			mockBehavior: func(s *mock_service.MockBoundary) {
+				s.EXPECT().GetBoundary(gomock.Any(), int64(42)).Return(models.Boundary{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Delete in use",
+			method: http.MethodDelete,
+			path:   "/api/v1/boundaries/1",
+			mockBehavior: func(s *mock_service.MockBoundary) {
+				s.EXPECT().DeleteBoundary(gomock.Any(), int64(1)).Return(models.ErrBoundaryInUse)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			boundary := mock_service.NewMockBoundary(ctrl)
+			testCase.mockBehavior(boundary)
+
+			handler := NewHandler(&services.Service{Boundary: boundary})
+
+			r := chi.NewRouter()
+			r.Route("/api/v1/boundaries", func(r chi.Router) {
+				r.Get("/", handler.ListBoundaries)
+				r.Post("/import", handler.ImportBoundaries)
+				r.Get("/{id}", handler.GetBoundary)
+				r.Delete("/{id}", handler.DeleteBoundary)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
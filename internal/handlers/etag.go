@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+var errInvalidETag = errors.New("invalid etag")
+
+func incidentETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ifMatchVersion возвращает версию из заголовка If-Match; 0 — заголовка нет
+// или передан «*». Слабые ETag для If-Match не допускаются (RFC 9110, 13.1.1).
+func ifMatchVersion(r *http.Request) (int64, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(v)
+	if err != nil || strings.HasPrefix(v, "W/") {
+		return 0, errInvalidETag
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidETag
+	}
+
+	return version, nil
+}
+
+// conditionalMeta дополняет changeMeta ожидаемой версией из If-Match.
+// Непригодный ETag ни с чем не совпадает, поэтому сразу отвечаем 412.
+func conditionalMeta(w http.ResponseWriter, r *http.Request) (models.ChangeMeta, bool) {
+	meta := changeMeta(r)
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writePreconditionFailed(w)
+		return meta, false
+	}
+	meta.IfVersion = version
+
+	return meta, true
+}
+
+func writePreconditionFailed(w http.ResponseWriter) {
+	common.WriteErrorResponse(w, http.StatusPreconditionFailed, "Инцидент был изменён, получите актуальную версию")
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_IncidentETag(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	body := `{"type":"fire","latitude":55.75,"longitude":37.61,"radius_meters":100,"active":true}`
+	req := models.IncidentRequest{Type: "fire", Latitude: 55.75, Longitude: 37.61, RadiusMeters: 100, Active: true}
+
+	testTable := []struct {
+		name               string
+		method             string
+		ifMatch            string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{
+			name:   "Get returns ETag",
+			method: http.MethodGet,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentById(4).Return(models.IncidentResponse{Type: "fire", Version: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"3"`,
+		},
+		{
+			name:    "Update with matching version",
+			method:  http.MethodPut,
+			ifMatch: `"3"`,
+			body:    body,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					UpdateIncident(4, req, models.ChangeMeta{IfVersion: 3}).
+					Return(models.IncidentResponse{Type: "fire", Version: 4}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:    "Update with stale version",
+			method:  http.MethodPut,
+			ifMatch: `"2"`,
+			body:    body,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					UpdateIncident(4, req, models.ChangeMeta{IfVersion: 2}).
+					Return(models.IncidentResponse{}, models.ErrVersionConflict)
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:               "Weak ETag",
+			method:             http.MethodPut,
+			ifMatch:            `W/"3"`,
+			body:               body,
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Delete with any version",
+			method:  http.MethodDelete,
+			ifMatch: "*",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().DeleteIncident(4, models.ChangeMeta{}).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:    "Delete with stale version",
+			method:  http.MethodDelete,
+			ifMatch: `"1"`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().DeleteIncident(4, models.ChangeMeta{IfVersion: 1}).Return(models.ErrVersionConflict)
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/incidents/{id}", handler.GetIncident)
+			r.Put("/api/v1/incidents/{id}", handler.UpdateIncident)
+			r.Delete("/api/v1/incidents/{id}", handler.DeleteIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/api/v1/incidents/4", bytes.NewBufferString(testCase.body))
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedETag, w.Header().Get("ETag"))
+		})
+	}
+}
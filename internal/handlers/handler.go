@@ -32,13 +32,62 @@ func (h *Handler) InitRoutes(cfg *config.Config, logger *slog.Logger) http.Handl
 
 			r.Use(middleware.APIKeyAuth)
 
-			r.Post("/", h.CreateIncidentHandler)
+			r.Post("/", h.idempotent(h.CreateIncidentHandler))
+			r.Post("/import", h.ImportIncidents)
 			r.Get("/", h.ListIncidents)
+			r.Get("/export", h.ExportIncidents)
+			r.Get("/search", h.SearchIncidents)
 			r.Get("/{id}", h.GetIncident)
 			r.Put("/{id}", h.UpdateIncident)
+			r.Patch("/{id}", h.PatchIncident)
 			r.Delete("/{id}", h.DeleteIncident)
+			r.Post("/{id}/restore", h.RestoreIncident)
+			r.Post("/{id}/merge", h.MergeIncident)
+			r.Post("/{id}/transitions", h.TransitionIncident)
+			r.Get("/{id}/transitions", h.GetIncidentTransitions)
+			r.Get("/{id}/history", h.GetIncidentHistory)
+			r.Post("/{id}/track", h.AddTrackPoints)
+			r.Get("/{id}/track", h.GetIncidentTrack)
+			r.Get("/{id}/occurrences", h.GetIncidentOccurrences)
+			r.Post("/{id}/occurrences/cancel", h.CancelOccurrence)
+			r.Get("/{id}/history/{revision}", h.GetIncidentRevision)
 			r.Get("/stats", h.GetStats)
 		})
+
+		// Справочник типов инцидентов
+		r.Route("/incident-types", func(r chi.Router) {
+
+			r.Use(middleware.APIKeyAuth)
+
+			r.Get("/", h.ListIncidentTypes)
+			r.Post("/", h.CreateIncidentType)
+			r.Get("/{code}", h.GetIncidentType)
+			r.Put("/{code}", h.UpdateIncidentType)
+			r.Delete("/{code}", h.DeleteIncidentType)
+		})
+
+		// Шаблоны инцидентов для быстрого ввода
+		r.Route("/incident-templates", func(r chi.Router) {
+
+			r.Use(middleware.APIKeyAuth)
+
+			r.Get("/", h.ListIncidentTemplates)
+			r.Post("/", h.CreateIncidentTemplate)
+			r.Get("/{id}", h.GetIncidentTemplate)
+			r.Put("/{id}", h.UpdateIncidentTemplate)
+			r.Delete("/{id}", h.DeleteIncidentTemplate)
+		})
+
+		// Административные границы: регион → город → район
+		r.Route("/boundaries", func(r chi.Router) {
+
+			r.Use(middleware.APIKeyAuth)
+
+			r.Get("/", h.ListBoundaries)
+			r.Post("/import", h.ImportBoundaries)
+			r.Get("/{id}", h.GetBoundary)
+			r.Delete("/{id}", h.DeleteBoundary)
+		})
 	})
 
 	return r
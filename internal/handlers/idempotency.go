@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+// replayedHeaders — заголовки ответа, которые воспроизводятся при повторе.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// idempotent делает POST-обработчик идемпотентным по заголовку Idempotency-Key:
+// ответ первого запроса сохраняется и отдаётся на повторы без повторного
+// выполнения, параллельный запрос с тем же ключом получает 409.
+// Ключи разделены по операторам.
+func (h *Handler) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "Idempotency-Key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(append([]byte(r.URL.RawQuery+"\n"), body...))
+		fingerprint := hex.EncodeToString(sum[:])
+		key = middleware.OperatorFromContext(r.Context()) + ":" + key
+
+		stored, err := h.services.BeginIdempotent(r.Context(), key, fingerprint)
+		switch {
+		case errors.Is(err, models.ErrIdempotencyInFlight):
+			common.WriteErrorResponse(w, http.StatusConflict, "Запрос с этим Idempotency-Key ещё выполняется")
+			return
+		case errors.Is(err, models.ErrIdempotencyKeyReused):
+			common.WriteErrorResponse(w, http.StatusUnprocessableEntity, "Idempotency-Key уже использован с другим запросом")
+			return
+		case err != nil:
+			log.Println(err)
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось проверить Idempotency-Key")
+			return
+		case stored != nil:
+			for name, value := range stored.Header {
+				w.Header().Set(name, value)
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.Status)
+			w.Write(stored.Body)
+			return
+		}
+
+		capture := &responseCapture{ResponseWriter: w, status: http.StatusOK}
+		next(capture, r)
+
+		// запрос мог быть отменён клиентом, а ответ всё равно нужно сохранить
+		ctx := context.WithoutCancel(r.Context())
+
+		if capture.status >= http.StatusInternalServerError {
+			if err := h.services.AbortIdempotent(ctx, key); err != nil {
+				log.Println(err)
+			}
+			return
+		}
+
+		resp := models.IdempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      capture.status,
+			Header:      map[string]string{},
+			Body:        capture.body.Bytes(),
+		}
+		for _, name := range replayedHeaders {
+			if v := w.Header().Get(name); v != "" {
+				resp.Header[name] = v
+			}
+		}
+
+		if err := h.services.CompleteIdempotent(ctx, key, resp); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// responseCapture пропускает ответ клиенту и запоминает статус и тело.
+type responseCapture struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (c *responseCapture) WriteHeader(status int) {
+	if !c.wroteHeader {
+		c.status = status
+		c.wroteHeader = true
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseCapture) Write(p []byte) (int, error) {
+	c.wroteHeader = true
+	c.body.Write(p)
+	return c.ResponseWriter.Write(p)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_CreateIncidentIdempotent(t *testing.T) {
+	type mockBehavior func(i *mock_service.MockIdempotency, s *mock_service.MockIncident)
+
+	body := `{"type":"fire","latitude":55.75,"longitude":37.61,"radius_meters":200,"active":true}`
+	req := models.IncidentRequest{Type: "fire", Latitude: 55.75, Longitude: 37.61, RadiusMeters: 200, Active: true}
+
+	testTable := []struct {
+		name                 string
+		key                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedReplayed     string
+		expectedETag         string
+	}{
+		{
+			name: "Without key",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).Return(models.IncidentResponse{ID: 3}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "First request",
+			key:  "k1",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				i.EXPECT().BeginIdempotent(gomock.Any(), ":k1", gomock.Any()).Return(nil, nil)
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).Return(models.IncidentResponse{ID: 3, Version: 1}, nil)
+				i.EXPECT().CompleteIdempotent(gomock.Any(), ":k1", gomock.Any()).
+					DoAndReturn(func(_ any, _ string, resp models.IdempotentResponse) error {
+						assert.Equal(t, http.StatusCreated, resp.Status)
+						assert.Equal(t, "/api/v1/incidents/3", resp.Header["Location"])
+						assert.Equal(t, `"1"`, resp.Header["ETag"])
+						assert.NotEmpty(t, resp.Fingerprint)
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Replay",
+			key:  "k1",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				i.EXPECT().BeginIdempotent(gomock.Any(), ":k1", gomock.Any()).Return(&models.IdempotentResponse{
+					State:  models.IdempotencyDone,
+					Status: http.StatusCreated,
+					Header: map[string]string{"Content-Type": "application/json", "ETag": `"1"`},
+					Body:   []byte(`{"type":"fire"}`),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"type":"fire"}`,
+			expectedReplayed:     "true",
+			expectedETag:         `"1"`,
+		},
+		{
+			name: "In flight",
+			key:  "k1",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				i.EXPECT().BeginIdempotent(gomock.Any(), ":k1", gomock.Any()).Return(nil, models.ErrIdempotencyInFlight)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"errors":"Запрос с этим Idempotency-Key ещё выполняется"}`,
+		},
+		{
+			name: "Key reused",
+			key:  "k1",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				i.EXPECT().BeginIdempotent(gomock.Any(), ":k1", gomock.Any()).Return(nil, models.ErrIdempotencyKeyReused)
+			},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"errors":"Idempotency-Key уже использован с другим запросом"}`,
+		},
+		{
+			name: "Server error frees key",
+			key:  "k1",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockIncident) {
+				i.EXPECT().BeginIdempotent(gomock.Any(), ":k1", gomock.Any()).Return(nil, nil)
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).Return(models.IncidentResponse{}, assert.AnError)
+				i.EXPECT().AbortIdempotent(gomock.Any(), ":k1").Return(nil)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"errors":"Не удалось добавить инцидент"}`,
+		},
+		{
+			name:                 "Key too long",
+			key:                  strings.Repeat("k", 256),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"errors":"Idempotency-Key is too long"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idempotency := mock_service.NewMockIdempotency(ctrl)
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(idempotency, incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident, Idempotency: idempotency})
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/incidents/", handler.idempotent(handler.CreateIncidentHandler))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/", bytes.NewBufferString(body))
+			if testCase.key != "" {
+				req.Header.Set("Idempotency-Key", testCase.key)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+			assert.Equal(t, testCase.expectedReplayed, w.Header().Get("Idempotent-Replayed"))
+			if testCase.expectedETag != "" {
+				assert.Equal(t, testCase.expectedETag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -1,32 +1,86 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi"
 	"github.com/rusinadaria/geo-notification-system/internal/common"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
 )
 
 func (h *Handler) CreateIncidentHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	var ref models.TemplateRef
+	if err := json.Unmarshal(body, &ref); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	// с template_id поля тела переопределяют поля шаблона
 	var incidentData models.IncidentRequest
-	if err := json.NewDecoder(r.Body).Decode(&incidentData); err != nil {
+	if ref.TemplateID != 0 {
+		incidentData, err = h.services.ExpandTemplate(r.Context(), ref, body)
+		if err != nil {
+			if errors.Is(err, models.ErrValidation) {
+				common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось применить шаблон инцидента")
+			return
+		}
+	} else if err := json.Unmarshal(body, &incidentData); err != nil {
 		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
 		return
 	}
 
-	err := h.services.CreateIncident(incidentData)
+	if err := incidentData.Validate(); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	force, err := parseBoolParam(r.URL.Query(), "force")
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	incident, err := h.services.CreateIncident(incidentData, changeMeta(r), force != nil && *force)
 	if err != nil {
+		var dup *services.DuplicateIncidentError
+		if errors.As(err, &dup) {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(models.DuplicateResponse{
+				Errors:     "Похожий инцидент уже существует, для создания передайте force=true",
+				Candidates: dup.Candidates,
+			})
+			return
+		}
+		if errors.Is(err, models.ErrValidation) {
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось добавить инцидент")
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/incidents/%d", incident.ID))
+	w.Header().Set("ETag", incidentETag(incident.Version))
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(incident)
 }
 
 func (h *Handler) ListIncidents(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +94,44 @@ func (h *Handler) ListIncidents(w http.ResponseWriter, r *http.Request) {
 		offset = 0
 	}
 
-	list, err := h.services.GetAllIncidents(limit, offset)
+	filter, err := parseIncidentFilter(r.URL.Query())
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+
+	// offset оставлен для совместимости, курсор предпочтительнее
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		cursor, err := models.DecodeCursor(raw)
+		if err != nil {
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !models.SupportsCursor(filter.Sort) {
+			common.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("sort %q does not support cursor pagination", filter.Sort))
+			return
+		}
+		if cursor.Sort != filter.Sort {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "cursor does not match sort order")
+			return
+		}
+		if offset > 0 {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "cursor and offset cannot be combined")
+			return
+		}
+		filter.After = &cursor
+	}
+
+	withTotal, err := parseBoolParam(r.URL.Query(), "include_total")
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.WithTotal = withTotal != nil && *withTotal
+
+	page, err := h.services.GetAllIncidents(filter)
 	if err != nil {
 		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить инциденты")
 		return
@@ -48,12 +139,11 @@ func (h *Handler) ListIncidents(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(list)
+	json.NewEncoder(w).Encode(page)
 }
 
 func (h *Handler) GetIncident(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "id"))
-	fmt.Println(id)
 	if err != nil {
 		http.Error(w, "invalid id", http.StatusBadRequest)
 		return
@@ -61,10 +151,15 @@ func (h *Handler) GetIncident(w http.ResponseWriter, r *http.Request) {
 
 	incident, err := h.services.GetIncidentById(id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
 		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось найти инцидент по id")
 		return
 	}
 
+	w.Header().Set("ETag", incidentETag(incident.Version))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(incident)
 }
@@ -83,17 +178,39 @@ func (h *Handler) UpdateIncident(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if newIncident.RadiusMeters <= 0 {
-		http.Error(w, "radius_meters must be > 0", http.StatusBadRequest)
+	if err := newIncident.Validate(); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
 		return
 	}
 
-	incident, err := h.services.UpdateIncident(id, newIncident)
+	incident, err := h.services.UpdateIncident(id, newIncident, meta)
 	if err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			writePreconditionFailed(w)
+			return
+		}
+		if errors.Is(err, models.ErrValidation) {
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
+		if errors.Is(err, models.ErrReviewedIncidentEdit) {
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+			return
+		}
 		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось изменить инцидент по id")
 		return
 	}
 
+	w.Header().Set("ETag", incidentETag(incident.Version))
 	json.NewEncoder(w).Encode(incident)
 }
 
@@ -104,13 +221,39 @@ func (h *Handler) DeleteIncident(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.services.DeleteIncident(id)
+	purge, err := parseBoolParam(r.URL.Query(), "purge")
 	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if purge != nil && *purge {
+		h.purgeIncident(w, r, id)
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
+		return
+	}
+
+	err = h.services.DeleteIncident(id, meta)
+	if err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			writePreconditionFailed(w)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
+		if errors.Is(err, models.ErrReviewedIncidentEdit) {
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+			return
+		}
 		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось удалить инцидент")
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/incidentio"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// ExportIncidents отдаёт инциденты в формате GeoJSON, KML или CSV. Строки
+// пишутся в ответ по мере чтения из базы, без буферизации всей выборки.
+func (h *Handler) ExportIncidents(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = incidentio.FormatGeoJSON
+	}
+
+	writer, err := incidentio.NewWriter(format, w)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "format must be geojson, kml or csv")
+		return
+	}
+
+	filter, err := parseIncidentFilter(r.URL.Query())
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil || filter.Limit < 0 {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "limit must be a non-negative integer")
+			return
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		filter.Offset, err = strconv.Atoi(v)
+		if err != nil || filter.Offset < 0 {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", writer.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="incidents.`+writer.Extension()+`"`)
+
+	// после первой записи статус уже не поменять, поэтому ошибки дальше только логируем
+	if err := writer.Begin(); err != nil {
+		log.Println(err)
+		return
+	}
+
+	err = h.services.ExportIncidents(r.Context(), filter, func(inc models.IncidentResponse) error {
+		return writer.Write(inc)
+	})
+	if err != nil {
+		log.Println("incident export failed:", err)
+		return
+	}
+
+	if err := writer.End(); err != nil {
+		log.Println(err)
+	}
+}
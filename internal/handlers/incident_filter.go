@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// parseIncidentFilter разбирает общие для списка и экспорта параметры фильтрации:
+//
+//	bbox=minLon,minLat,maxLon,maxLat
+//	lat, lon, within          — зоны не дальше within метров от точки
+//	type=fire,flood           — один или несколько кодов справочника типов
+//	status=draft,pending_review — одно или несколько состояний согласования
+//	active, live              — true/false
+//	include_deleted=true      — показать и удалённые инциденты
+//	created_from, created_to, updated_from, updated_to — RFC 3339
+//	sort=-created_at|created_at|-updated_at|updated_at|distance
+//
+// limit и offset обрабатывает сам эндпоинт.
+func parseIncidentFilter(q url.Values) (models.IncidentFilter, error) {
+	var (
+		filter models.IncidentFilter
+		err    error
+	)
+
+	if v := q.Get("bbox"); v != "" {
+		if filter.BBox, err = parseBBox(v); err != nil {
+			return filter, err
+		}
+	}
+
+	lat, lon := q.Get("lat"), q.Get("lon")
+	if lat != "" || lon != "" {
+		if filter.Point, err = parsePoint(lat, lon); err != nil {
+			return filter, err
+		}
+	}
+
+	if v := q.Get("within"); v != "" {
+		within, err := strconv.ParseFloat(v, 64)
+		if err != nil || within < 0 {
+			return filter, errors.New("within must be a non-negative number of meters")
+		}
+		if filter.Point == nil {
+			return filter, errors.New("within requires lat and lon")
+		}
+		filter.WithinMeters = &within
+	}
+
+	for _, v := range q["type"] {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				filter.Types = append(filter.Types, t)
+			}
+		}
+	}
+
+	for _, v := range q["status"] {
+		for _, s := range strings.Split(v, ",") {
+			status := models.IncidentStatus(strings.TrimSpace(s))
+			if status == "" {
+				continue
+			}
+			if !status.Valid() {
+				return filter, fmt.Errorf("unknown status %q", status)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	if filter.Active, err = parseBoolParam(q, "active"); err != nil {
+		return filter, err
+	}
+	if filter.Live, err = parseBoolParam(q, "live"); err != nil {
+		return filter, err
+	}
+	includeDeleted, err := parseBoolParam(q, "include_deleted")
+	if err != nil {
+		return filter, err
+	}
+	filter.IncludeDeleted = includeDeleted != nil && *includeDeleted
+
+	for name, dst := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"updated_from": &filter.UpdatedFrom,
+		"updated_to":   &filter.UpdatedTo,
+	} {
+		if *dst, err = parseTimeParam(q, name); err != nil {
+			return filter, err
+		}
+	}
+
+	filter.Sort = q.Get("sort")
+	switch filter.Sort {
+	case "":
+		filter.Sort = models.SortCreatedDesc
+	case models.SortCreatedDesc, models.SortCreatedAsc, models.SortUpdatedDesc, models.SortUpdatedAsc:
+	case models.SortDistance:
+		if filter.Point == nil {
+			return filter, errors.New("sort=distance requires lat and lon")
+		}
+	default:
+		return filter, fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+
+	return filter, nil
+}
+
+func parseBBox(v string) (*models.BBox, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox must be minLon,minLat,maxLon,maxLat")
+	}
+
+	var coords [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, errors.New("bbox must contain four numbers")
+		}
+		coords[i] = f
+	}
+
+	b := &models.BBox{MinLon: coords[0], MinLat: coords[1], MaxLon: coords[2], MaxLat: coords[3]}
+	if b.MinLon < -180 || b.MaxLon > 180 || b.MinLat < -90 || b.MaxLat > 90 ||
+		b.MinLon >= b.MaxLon || b.MinLat >= b.MaxLat {
+		return nil, errors.New("bbox is out of range or empty")
+	}
+
+	return b, nil
+}
+
+func parsePoint(lat, lon string) (*models.GeoPoint, error) {
+	latF, err := strconv.ParseFloat(lat, 64)
+	if err != nil || latF < -90 || latF > 90 {
+		return nil, errors.New("lat must be between -90 and 90")
+	}
+
+	lonF, err := strconv.ParseFloat(lon, 64)
+	if err != nil || lonF < -180 || lonF > 180 {
+		return nil, errors.New("lon must be between -180 and 180")
+	}
+
+	return &models.GeoPoint{Lat: latF, Lon: lonF}, nil
+}
+
+func parseBoolParam(q url.Values, name string) (*bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", name)
+	}
+
+	return &b, nil
+}
+
+func parseTimeParam(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+	}
+
+	return &t, nil
+}
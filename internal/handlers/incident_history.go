@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// Причина изменения передаётся заголовком, так как у DELETE нет тела запроса.
+const changeReasonHeader = "X-Change-Reason"
+
+func changeMeta(r *http.Request) models.ChangeMeta {
+	return models.ChangeMeta{
+		Operator: middleware.OperatorFromContext(r.Context()),
+		Role:     middleware.RoleFromContext(r.Context()),
+		Reason:   r.Header.Get(changeReasonHeader),
+	}
+}
+
+func (h *Handler) GetIncidentHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.services.GetIncidentHistory(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить историю инцидента")
+		return
+	}
+
+	if history == nil {
+		history = []models.IncidentRevision{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+func (h *Handler) GetIncidentRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil || revision <= 0 {
+		http.Error(w, "invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	incident, err := h.services.GetIncidentRevision(id, revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Ревизия инцидента не найдена")
+			return
+		}
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить ревизию инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(incident)
+}
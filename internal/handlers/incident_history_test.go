@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_GetIncidentHistory(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := []models.IncidentRevision{
+		{
+			IncidentID: 2,
+			Revision:   1,
+			Action:     models.RevisionCreate,
+			Operator:   "alice",
+			After:      json.RawMessage(`{"type":"fire"}`),
+			CreatedAt:  fixedTime,
+		},
+		{
+			IncidentID: 2,
+			Revision:   2,
+			Action:     models.RevisionUpdate,
+			Operator:   "bob",
+			Reason:     "zone moved",
+			Before:     json.RawMessage(`{"type":"fire"}`),
+			After:      json.RawMessage(`{"type":"flood"}`),
+			CreatedAt:  fixedTime,
+		},
+	}
+
+	testTable := []struct {
+		name                 string
+		id                   string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			id:   "2",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentHistory(2).Return(history, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: func() string {
+				body, _ := json.Marshal(history)
+				return string(body)
+			}(),
+		},
+		{
+			name: "Not found",
+			id:   "5",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentHistory(5).Return(nil, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			mockBehavior:       func(s *mock_service.MockIncident) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			testCase.mockBehavior(incident)
+
+			services := &services.Service{Incident: incident}
+			handler := NewHandler(services)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/incidents/{id}/history", handler.GetIncidentHistory)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/"+testCase.id+"/history", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_DeleteIncidentChangeMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	incident := mock_service.NewMockIncident(ctrl)
+	incident.EXPECT().
+		DeleteIncident(7, models.ChangeMeta{Operator: "", Reason: "false alarm"}).
+		Return(nil)
+
+	handler := NewHandler(&services.Service{Incident: incident})
+
+	r := chi.NewRouter()
+	r.Delete("/api/v1/incidents/{id}", handler.DeleteIncident)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/incidents/7", nil)
+	req.Header.Set("X-Change-Reason", "false alarm")
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/incidentio"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const maxImportSize = 10 << 20
+
+// ImportIncidents принимает GeoJSON FeatureCollection или CSV. Формат берётся из
+// параметра format, а если он не задан — из Content-Type.
+func (h *Handler) ImportIncidents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = models.ImportModeAtomic
+	}
+	if mode != models.ImportModeAtomic && mode != models.ImportModePartial {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "mode must be atomic or partial")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importFormat(r.Header.Get("Content-Type"))
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusRequestEntityTooLarge, "Файл импорта слишком большой")
+		return
+	}
+
+	var rows []models.ImportRow
+	switch format {
+	case incidentio.FormatGeoJSON:
+		rows, err = incidentio.ParseGeoJSON(data)
+	case incidentio.FormatCSV:
+		rows, err = incidentio.ParseCSV(bytes.NewReader(data))
+	default:
+		common.WriteErrorResponse(w, http.StatusUnsupportedMediaType, "format must be geojson or csv")
+		return
+	}
+	if err != nil {
+		if errors.Is(err, incidentio.ErrInvalidFile) {
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось прочитать файл импорта")
+		return
+	}
+
+	result, err := h.services.ImportIncidents(rows, mode, changeMeta(r))
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось импортировать инциденты")
+		return
+	}
+
+	code := http.StatusOK
+	if mode == models.ImportModeAtomic && len(result.Errors) > 0 {
+		code = http.StatusUnprocessableEntity
+	}
+
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
+}
+
+func importFormat(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "text/csv":
+		return incidentio.FormatCSV
+	case "", "application/json", "application/geo+json":
+		return incidentio.FormatGeoJSON
+	default:
+		return mediaType
+	}
+}
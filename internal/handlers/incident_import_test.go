@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_ImportIncidents(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	const csv = "type,latitude,longitude,radius_meters\n" +
+		"fire,55.75,37.61,200\n" +
+		"smoke,55.76,37.62,100\n"
+
+	// вторая строка не сохранилась: тип удалён из справочника после проверки
+	failed := models.ImportResult{
+		Total:      2,
+		CreatedIDs: []int64{7},
+		Errors:     []models.ImportError{{Line: 3, Error: `validation error: unknown incident type "smoke"`}},
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		contentType          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Partial with failed row",
+			query:       "?mode=partial",
+			contentType: "text/csv",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					ImportIncidents(gomock.Len(2), models.ImportModePartial, gomock.Any()).
+					DoAndReturn(func(rows []models.ImportRow, mode string, _ models.ChangeMeta) (models.ImportResult, error) {
+						assert.Equal(t, "smoke", rows[1].Request.Type)
+						assert.Equal(t, 3, rows[1].Line)
+
+						result := failed
+						result.Mode = mode
+						return result, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"mode":"partial","total":2,"created_ids":[7],"errors":[{"line":3,"error":"validation error: unknown incident type \"smoke\""}]}`,
+		},
+		{
+			name:        "Atomic with failed row",
+			contentType: "text/csv",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					ImportIncidents(gomock.Len(2), models.ImportModeAtomic, gomock.Any()).
+					Return(models.ImportResult{
+						Mode:       models.ImportModeAtomic,
+						Total:      2,
+						CreatedIDs: []int64{},
+						Errors:     failed.Errors,
+					}, nil)
+			},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"mode":"atomic","total":2,"created_ids":[],"errors":[{"line":3,"error":"validation error: unknown incident type \"smoke\""}]}`,
+		},
+		{
+			name:                 "Invalid mode",
+			query:                "?mode=best-effort",
+			contentType:          "text/csv",
+			mockBehavior:         func(s *mock_service.MockIncident) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"errors":"mode must be atomic or partial"}`,
+		},
+		{
+			name:                 "Unsupported format",
+			contentType:          "application/xml",
+			mockBehavior:         func(s *mock_service.MockIncident) {},
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: `{"errors":"format must be geojson or csv"}`,
+		},
+		{
+			name:        "Service failure",
+			query:       "?mode=partial",
+			contentType: "text/csv",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					ImportIncidents(gomock.Any(), models.ImportModePartial, gomock.Any()).
+					Return(models.ImportResult{}, errors.New("connection reset"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"errors":"Не удалось импортировать инциденты"}`,
+		},
+	}
+
+	t.Setenv("OPERATOR_KEYS", "alice:alice-key")
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			testCase.mockBehavior(incident)
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.With(middleware.APIKeyAuth).Post("/api/v1/incidents/import", handler.ImportIncidents)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/import"+testCase.query, strings.NewReader(csv))
+			req.Header.Set("API-Key", "alice-key")
+			req.Header.Set("Content-Type", testCase.contentType)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// MergeIncident присоединяет дубль из тела запроса к инциденту {id}.
+// If-Match, если передан, проверяется по версии основного инцидента.
+func (h *Handler) MergeIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.DuplicateID <= 0 {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "duplicate_id is required")
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
+		return
+	}
+
+	incident, err := h.services.MergeIncidents(id, int(req.DuplicateID), meta)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrValidation):
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrMergeConflict):
+			common.WriteErrorResponse(w, http.StatusConflict, "Дубль уже удалён или имеет другой тип")
+		case errors.Is(err, models.ErrReviewedIncidentEdit):
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+		case errors.Is(err, models.ErrVersionConflict):
+			writePreconditionFailed(w)
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось объединить инциденты")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", incidentETag(incident.Version))
+	json.NewEncoder(w).Encode(incident)
+}
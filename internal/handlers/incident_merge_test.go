@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_CreateIncidentDuplicates(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"type":"fire","latitude":55.75,"longitude":37.61,"radius_meters":200,"active":true}`
+	req := models.IncidentRequest{Type: "fire", Latitude: 55.75, Longitude: 37.61, RadiusMeters: 200, Active: true}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Duplicate found",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).Return(models.IncidentResponse{}, &services.DuplicateIncidentError{
+					Candidates: []models.DuplicateCandidate{
+						{ID: 7, Type: "fire", Severity: models.SeverityDanger, Overlap: 0.8, CreatedAt: fixedTime},
+					},
+				})
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponseBody: `{"errors":"Похожий инцидент уже существует, для создания передайте force=true",
+				"candidates":[{"id":7,"type":"fire","severity":"danger","description":"","overlap":0.8,"created_at":"2025-01-01T12:00:00Z"}]}`,
+		},
+		{
+			name:  "Forced",
+			query: "?force=true",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), true).Return(models.IncidentResponse{ID: 8}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Invalid force",
+			query:              "?force=maybe",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/incidents/", handler.CreateIncidentHandler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/"+testCase.query, bytes.NewBufferString(body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_MergeIncident(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	testTable := []struct {
+		name               string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"duplicate_id": 8}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					MergeIncidents(5, 8, gomock.Any()).
+					Return(models.IncidentResponse{Type: "fire", Version: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Already merged",
+			body: `{"duplicate_id": 8}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					MergeIncidents(5, 8, gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrMergeConflict)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Published primary by operator",
+			body: `{"duplicate_id": 8}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					MergeIncidents(5, 8, gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Missing duplicate",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/incidents/{id}/merge", handler.MergeIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/5/merge", bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const (
+	defaultOccurrenceLimit = 10
+	maxOccurrenceLimit     = 100
+)
+
+// GetIncidentOccurrences отдаёт ближайшие повторения инцидента начиная с
+// параметра from (по умолчанию — сейчас), включая идущее.
+func (h *Handler) GetIncidentOccurrences(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	from := time.Now()
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "from must be an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	limit := defaultOccurrenceLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > maxOccurrenceLimit {
+			common.WriteErrorResponse(w, http.StatusBadRequest, "limit must be between 1 and 100")
+			return
+		}
+	}
+
+	occurrences, err := h.services.GetIncidentOccurrences(r.Context(), id, from, limit)
+	if err != nil {
+		writeOccurrenceError(w, err, "Не удалось получить повторения инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(occurrences)
+}
+
+// CancelOccurrence отменяет одно повторение, не меняя серию.
+func (h *Handler) CancelOccurrence(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.OccurrenceCancelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.StartsAt.IsZero() {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "starts_at is required")
+		return
+	}
+
+	if err := h.services.CancelOccurrence(r.Context(), id, req.StartsAt, changeMeta(r)); err != nil {
+		writeOccurrenceError(w, err, "Не удалось отменить повторение")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeOccurrenceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+	case errors.Is(err, models.ErrNotRecurring):
+		common.WriteErrorResponse(w, http.StatusConflict, "Инцидент не повторяется")
+	case errors.Is(err, models.ErrReviewedIncidentEdit):
+		common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+	default:
+		common.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
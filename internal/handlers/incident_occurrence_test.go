@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_IncidentOccurrences(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	from := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+	start := from.Add(10 * time.Hour)
+
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "List",
+			method: http.MethodGet,
+			path:   "/api/v1/incidents/5/occurrences?from=2026-10-03T00:00:00Z&limit=2",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentOccurrences(gomock.Any(), 5, from, 2).Return([]models.Occurrence{
+					{StartsAt: start, EndsAt: start.Add(4 * time.Hour)},
+					{StartsAt: start.AddDate(0, 0, 7), EndsAt: start.AddDate(0, 0, 7).Add(4 * time.Hour), Cancelled: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `[
+				{"starts_at":"2026-10-03T10:00:00Z","ends_at":"2026-10-03T14:00:00Z"},
+				{"starts_at":"2026-10-10T10:00:00Z","ends_at":"2026-10-10T14:00:00Z","cancelled":true}
+			]`,
+		},
+		{
+			name:               "Invalid limit",
+			method:             http.MethodGet,
+			path:               "/api/v1/incidents/5/occurrences?limit=1000",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Not recurring",
+			method: http.MethodGet,
+			path:   "/api/v1/incidents/6/occurrences",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentOccurrences(gomock.Any(), 6, gomock.Any(), 10).Return(nil, models.ErrNotRecurring)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Cancel",
+			method: http.MethodPost,
+			path:   "/api/v1/incidents/5/occurrences/cancel",
+			body:   `{"starts_at":"2026-10-03T10:00:00Z"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().CancelOccurrence(gomock.Any(), 5, start, gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Cancel without start",
+			method:             http.MethodPost,
+			path:               "/api/v1/incidents/5/occurrences/cancel",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Cancel unknown incident",
+			method: http.MethodPost,
+			path:   "/api/v1/incidents/7/occurrences/cancel",
+			body:   `{"starts_at":"2026-10-03T10:00:00Z"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().CancelOccurrence(gomock.Any(), 7, start, gomock.Any()).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Cancel published occurrence by operator",
+			method: http.MethodPost,
+			path:   "/api/v1/incidents/5/occurrences/cancel",
+			body:   `{"starts_at":"2026-10-03T10:00:00Z"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().CancelOccurrence(gomock.Any(), 5, start, gomock.Any()).Return(models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/incidents/{id}/occurrences", handler.GetIncidentOccurrences)
+			r.Post("/api/v1/incidents/{id}/occurrences/cancel", handler.CancelOccurrence)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// PatchIncident частично изменяет инцидент по документу JSON Merge Patch (RFC 7396).
+func (h *Handler) PatchIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, _ := mime.ParseMediaType(ct)
+		if mediaType != mergePatchContentType && mediaType != "application/json" {
+			w.Header().Set("Accept-Patch", mergePatchContentType)
+			common.WriteErrorResponse(w, http.StatusUnsupportedMediaType, "Ожидается application/merge-patch+json")
+			return
+		}
+	}
+
+	patch, err := decodeIncidentPatch(r)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
+		return
+	}
+
+	incident, err := h.services.PatchIncident(id, patch, meta)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrVersionConflict):
+			writePreconditionFailed(w)
+		case errors.Is(err, models.ErrValidation):
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrReviewedIncidentEdit):
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось изменить инцидент по id")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", incidentETag(incident.Version))
+	json.NewEncoder(w).Encode(incident)
+}
+
+// decodeIncidentPatch требует JSON-объект и отклоняет неизвестные и
+// вычисляемые поля (live, created_at и т.п.).
+func decodeIncidentPatch(r *http.Request) (models.IncidentPatch, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		return models.IncidentPatch{}, errors.New("invalid body")
+	}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 || trimmed[0] != '{' {
+		return models.IncidentPatch{}, errors.New("merge patch must be a JSON object")
+	}
+
+	var patch models.IncidentPatch
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patch); err != nil {
+		return models.IncidentPatch{}, err
+	}
+
+	return patch, nil
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_PatchIncident(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		id                   string
+		contentType          string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Radius only",
+			id:          "3",
+			contentType: "application/merge-patch+json",
+			body:        `{"radius_meters": 250}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					PatchIncident(3, models.IncidentPatch{
+						RadiusMeters: models.Field[int]{Set: true, Value: 250},
+					}, gomock.Any()).
+					Return(models.IncidentResponse{
+						ID:           3,
+						Type:         "fire",
+						Severity:     models.SeverityDanger,
+						Latitude:     55.75,
+						Longitude:    37.61,
+						RadiusMeters: 250,
+						Active:       true,
+						CreatedAt:    fixedTime,
+						UpdatedAt:    fixedTime,
+					}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":3,"type":"fire","severity":"danger","description":"","latitude":55.75,"longitude":37.61,"radius_meters":250,"active":true,"live":false,"created_at":"2025-01-01T12:00:00Z","updated_at":"2025-01-01T12:00:00Z"}`,
+		},
+		{
+			name: "Null clears window",
+			id:   "3",
+			body: `{"ends_at": null, "active": false}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					PatchIncident(3, models.IncidentPatch{
+						EndsAt: models.Field[time.Time]{Set: true, Null: true},
+						Active: models.Field[bool]{Set: true},
+					}, gomock.Any()).
+					Return(models.IncidentResponse{Type: "fire"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Validation error",
+			id:   "3",
+			body: `{"type": null}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					PatchIncident(3, gomock.Any(), gomock.Any()).
+					Return(models.IncidentResponse{}, fmt.Errorf("%w: type cannot be null", models.ErrValidation))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Not found",
+			id:   "9",
+			body: `{"description": "x"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					PatchIncident(9, gomock.Any(), gomock.Any()).
+					Return(models.IncidentResponse{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Published incident by operator",
+			id:   "3",
+			body: `{"severity": "critical"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					PatchIncident(3, gomock.Any(), gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Not an object",
+			id:                 "3",
+			body:               `[{"op": "replace"}]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Read-only field",
+			id:                 "3",
+			body:               `{"live": true}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unsupported media type",
+			id:                 "3",
+			contentType:        "application/json-patch+json",
+			body:               `[]`,
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Patch("/api/v1/incidents/{id}", handler.PatchIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/incidents/"+testCase.id, bytes.NewBufferString(testCase.body))
+			if testCase.contentType != "" {
+				req.Header.Set("Content-Type", testCase.contentType)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// RestoreIncident снова включает удалённый инцидент.
+func (h *Handler) RestoreIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
+		return
+	}
+
+	incident, err := h.services.RestoreIncident(id, meta)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrIncidentNotDeleted):
+			common.WriteErrorResponse(w, http.StatusConflict, "Инцидент не удалён")
+		case errors.Is(err, models.ErrReviewedIncidentEdit):
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+		case errors.Is(err, models.ErrVersionConflict):
+			writePreconditionFailed(w)
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось восстановить инцидент")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", incidentETag(incident.Version))
+	json.NewEncoder(w).Encode(incident)
+}
+
+// purgeIncident обрабатывает DELETE /incidents/{id}?purge=true. Доступно
+// только администраторам и только после истечения срока хранения.
+func (h *Handler) purgeIncident(w http.ResponseWriter, r *http.Request, id int) {
+	if middleware.RoleFromContext(r.Context()) != middleware.RoleAdmin {
+		common.WriteErrorResponse(w, http.StatusForbidden, "Окончательное удаление доступно только администратору")
+		return
+	}
+
+	err := h.services.PurgeIncident(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrIncidentNotDeleted):
+			common.WriteErrorResponse(w, http.StatusConflict, "Сначала инцидент нужно удалить")
+		case errors.Is(err, models.ErrRetentionPeriod):
+			common.WriteErrorResponse(w, http.StatusConflict, "Срок хранения удалённого инцидента ещё не истёк")
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось удалить инцидент")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
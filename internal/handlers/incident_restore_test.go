@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_RestoreIncident(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	testTable := []struct {
+		name               string
+		id                 string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "4",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					RestoreIncident(4, models.ChangeMeta{Operator: "alice", Role: middleware.RoleOperator}).
+					Return(models.IncidentResponse{Type: "fire", Active: true, Version: 5}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not deleted",
+			id:   "4",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					RestoreIncident(4, gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrIncidentNotDeleted)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Published incident by operator",
+			id:   "4",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					RestoreIncident(4, gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "Not found",
+			id:   "8",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					RestoreIncident(8, gomock.Any()).
+					Return(models.IncidentResponse{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	t.Setenv("OPERATOR_KEYS", "alice:alice-key")
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			testCase.mockBehavior(incident)
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.With(middleware.APIKeyAuth).Post("/api/v1/incidents/{id}/restore", handler.RestoreIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/"+testCase.id+"/restore", nil)
+			req.Header.Set("API-Key", "alice-key")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_PurgeIncident(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	testTable := []struct {
+		name               string
+		apiKey             string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:   "Admin",
+			apiKey: "root-key",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().PurgeIncident(gomock.Any(), 4).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "Retention not elapsed",
+			apiKey: "root-key",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().PurgeIncident(gomock.Any(), 4).Return(models.ErrRetentionPeriod)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "Operator is forbidden",
+			apiKey:             "alice-key",
+			mockBehavior:       func(s *mock_service.MockIncident) {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	t.Setenv("OPERATOR_KEYS", "alice:alice-key,root:root-key:admin")
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			testCase.mockBehavior(incident)
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.With(middleware.APIKeyAuth).Delete("/api/v1/incidents/{id}", handler.DeleteIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/incidents/4?purge=true", nil)
+			req.Header.Set("API-Key", testCase.apiKey)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const maxSearchQueryLen = 200
+
+// SearchIncidents — полнотекстовый поиск по инцидентам. Принимает q и те же
+// фильтры, что и список; без явного sort результаты упорядочены по релевантности.
+func (h *Handler) SearchIncidents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	text := strings.TrimSpace(q.Get("q"))
+	if text == "" {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	if utf8.RuneCountInString(text) > maxSearchQueryLen {
+		common.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("q must be at most %d characters", maxSearchQueryLen))
+		return
+	}
+
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(q.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	relevance := q.Get("sort") == "" || q.Get("sort") == models.SortRelevance
+	if relevance {
+		q.Del("sort")
+	}
+
+	filter, err := parseIncidentFilter(q)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+	if relevance {
+		filter.Sort = models.SortRelevance
+	}
+
+	result, err := h.services.SearchIncidents(r.Context(), text, filter)
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось выполнить поиск")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
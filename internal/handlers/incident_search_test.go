@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_SearchIncidents(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Relevance by default",
+			query: "?q=" + url.QueryEscape("пожар склад"),
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					SearchIncidents(gomock.Any(), "пожар склад", models.IncidentFilter{Limit: 10, Sort: models.SortRelevance}).
+					Return(models.IncidentSearchResult{
+						Items: []models.IncidentSearchHit{{
+							IncidentResponse: models.IncidentResponse{ID: 4, Type: "fire", Severity: models.SeverityDanger},
+							Rank:             0.5,
+							Snippet:          "<mark>Пожар</mark> на <mark>складе</mark>",
+						}},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"items":[{"id":4,"type":"fire","severity":"danger","description":"","latitude":0,"longitude":0,
+				"radius_meters":0,"active":false,"live":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z",
+				"rank":0.5,"snippet":"<mark>Пожар</mark> на <mark>складе</mark>"}],"has_more":false}`,
+		},
+		{
+			name:  "With list filters",
+			query: "?q=flood&type=flood&active=true&sort=-updated_at&limit=5&offset=5",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				active := true
+				s.EXPECT().
+					SearchIncidents(gomock.Any(), "flood", models.IncidentFilter{
+						Limit:  5,
+						Offset: 5,
+						Types:  []string{"flood"},
+						Active: &active,
+						Sort:   models.SortUpdatedDesc,
+					}).
+					Return(models.IncidentSearchResult{Items: []models.IncidentSearchHit{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"items":[],"has_more":false}`,
+		},
+		{
+			name:               "Missing query",
+			query:              "?q=%20",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid filter",
+			query:              "?q=fire&bbox=1,2",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Service error",
+			query: "?q=fire",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					SearchIncidents(gomock.Any(), "fire", gomock.Any()).
+					Return(models.IncidentSearchResult{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/incidents/search", handler.SearchIncidents)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/search"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+func (h *Handler) ListIncidentTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.services.ListIncidentTemplates(r.Context())
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить шаблоны инцидентов")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+func (h *Handler) GetIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.services.GetIncidentTemplate(r.Context(), id)
+	if err != nil {
+		writeIncidentTemplateError(w, err, "Не удалось получить шаблон инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+func (h *Handler) CreateIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	var t models.IncidentTemplate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	created, err := h.services.CreateIncidentTemplate(r.Context(), t)
+	if err != nil {
+		writeIncidentTemplateError(w, err, "Не удалось добавить шаблон инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// UpdateIncidentTemplate заменяет шаблон; id берётся из пути.
+func (h *Handler) UpdateIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var t models.IncidentTemplate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+	t.ID = id
+
+	updated, err := h.services.UpdateIncidentTemplate(r.Context(), t)
+	if err != nil {
+		writeIncidentTemplateError(w, err, "Не удалось изменить шаблон инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (h *Handler) DeleteIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.services.DeleteIncidentTemplate(r.Context(), id); err != nil {
+		writeIncidentTemplateError(w, err, "Не удалось удалить шаблон инцидента")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeIncidentTemplateError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		common.WriteErrorResponse(w, http.StatusNotFound, "Шаблон инцидента не найден")
+	case errors.Is(err, models.ErrIncidentTemplateExists):
+		common.WriteErrorResponse(w, http.StatusConflict, "Шаблон с таким именем уже существует")
+	default:
+		common.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
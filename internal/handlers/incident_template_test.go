@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_IncidentTemplates(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncidentTemplate)
+
+	leak := models.IncidentTemplate{
+		ID:           3,
+		Name:         "Утечка газа",
+		Type:         "gas_leak",
+		Severity:     models.SeverityDanger,
+		Description:  "Утечка газа по адресу {{street}}",
+		RadiusMeters: 200,
+	}
+
+	testTable := []struct {
+		name               string
+		method             string
+		path               string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-templates/",
+			body:   `{"name":"Утечка газа","type":"gas_leak","severity":"danger","description":"Утечка газа по адресу {{street}}","radius_meters":200}`,
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				req := leak
+				req.ID = 0
+				s.EXPECT().CreateIncidentTemplate(gomock.Any(), req).Return(leak, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "Create unknown type",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-templates/",
+			body:   `{"name":"Смог","type":"smog"}`,
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				s.EXPECT().CreateIncidentTemplate(gomock.Any(), gomock.Any()).
+					Return(models.IncidentTemplate{}, models.ErrUnknownIncidentType)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Create duplicate",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-templates/",
+			body:   `{"name":"Утечка газа","type":"gas_leak"}`,
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				s.EXPECT().CreateIncidentTemplate(gomock.Any(), gomock.Any()).
+					Return(models.IncidentTemplate{}, models.ErrIncidentTemplateExists)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Update takes id from path",
+			method: http.MethodPut,
+			path:   "/api/v1/incident-templates/3",
+			body:   `{"id":7,"name":"Утечка газа","type":"gas_leak","severity":"danger","description":"Утечка газа по адресу {{street}}","radius_meters":200}`,
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				s.EXPECT().UpdateIncidentTemplate(gomock.Any(), leak).Return(leak, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Get unknown",
+			method: http.MethodGet,
+			path:   "/api/v1/incident-templates/9",
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				s.EXPECT().GetIncidentTemplate(gomock.Any(), int64(9)).Return(models.IncidentTemplate{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid id",
+			method:             http.MethodDelete,
+			path:               "/api/v1/incident-templates/abc",
+			mockBehavior:       func(s *mock_service.MockIncidentTemplate) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			path:   "/api/v1/incident-templates/3",
+			mockBehavior: func(s *mock_service.MockIncidentTemplate) {
+				s.EXPECT().DeleteIncidentTemplate(gomock.Any(), int64(3)).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			template := mock_service.NewMockIncidentTemplate(ctrl)
+			testCase.mockBehavior(template)
+
+			handler := NewHandler(&services.Service{IncidentTemplate: template})
+
+			r := chi.NewRouter()
+			r.Route("/api/v1/incident-templates", func(r chi.Router) {
+				r.Post("/", handler.CreateIncidentTemplate)
+				r.Get("/{id}", handler.GetIncidentTemplate)
+				r.Put("/{id}", handler.UpdateIncidentTemplate)
+				r.Delete("/{id}", handler.DeleteIncidentTemplate)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_CreateIncidentFromTemplate(t *testing.T) {
+	type mockBehavior func(tpl *mock_service.MockIncidentTemplate, inc *mock_service.MockIncident)
+
+	expanded := models.IncidentRequest{
+		Type:         "gas_leak",
+		Severity:     models.SeverityDanger,
+		Description:  "Утечка газа по адресу ул. Ленина, 5",
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 200,
+		Active:       true,
+	}
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"template_id":3,"placeholders":{"street":"ул. Ленина, 5"},"latitude":55.75,"longitude":37.61,"active":true}`,
+			mockBehavior: func(tpl *mock_service.MockIncidentTemplate, inc *mock_service.MockIncident) {
+				tpl.EXPECT().ExpandTemplate(gomock.Any(),
+					models.TemplateRef{TemplateID: 3, Placeholders: map[string]string{"street": "ул. Ленина, 5"}},
+					gomock.Any(),
+				).Return(expanded, nil)
+				inc.EXPECT().CreateIncident(expanded, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 12, Type: "gas_leak", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:      "Missing placeholder",
+			inputBody: `{"template_id":3,"latitude":55.75,"longitude":37.61}`,
+			mockBehavior: func(tpl *mock_service.MockIncidentTemplate, inc *mock_service.MockIncident) {
+				tpl.EXPECT().ExpandTemplate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.IncidentRequest{}, fmt.Errorf("%w: no value for placeholder {{street}}", models.ErrValidation))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Expanded request is validated",
+			inputBody: `{"template_id":3,"placeholders":{"street":"ул. Ленина, 5"}}`,
+			mockBehavior: func(tpl *mock_service.MockIncidentTemplate, inc *mock_service.MockIncident) {
+				req := expanded
+				req.Latitude = 120
+				tpl.EXPECT().ExpandTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(req, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			template := mock_service.NewMockIncidentTemplate(c)
+			incident := mock_service.NewMockIncident(c)
+			testCase.mockBehavior(template, incident)
+
+			handler := NewHandler(&services.Service{Incident: incident, IncidentTemplate: template})
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/incidents/", handler.CreateIncidentHandler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/incidents/", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +28,7 @@ func TestHandler_CreateIncident(t *testing.T) {
 		inputReq           models.IncidentRequest
 		mockBehavior       mockBehavior
 		expectedStatusCode int
+		expectedLocation   string
 	}{
 		{
 			name: "OK",
@@ -47,9 +49,299 @@ func TestHandler_CreateIncident(t *testing.T) {
 				Active:       true,
 			},
 			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
-				s.EXPECT().CreateIncident(req)
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 5, Type: "danger", Version: 1}, nil)
 			},
-			expectedStatusCode: 200,
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/5",
+		},
+		{
+			name: "OK polygon",
+			inputBody: `{
+				"type": "flood",
+				"description": "desc_flood",
+				"geometry": {"type": "Polygon", "coordinates": [[[37.6, 55.7], [37.7, 55.7], [37.7, 55.8], [37.6, 55.7]]]},
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:        "flood",
+				Description: "desc_flood",
+				Geometry:    json.RawMessage(`{"type": "Polygon", "coordinates": [[[37.6, 55.7], [37.7, 55.7], [37.7, 55.8], [37.6, 55.7]]]}`),
+				Active:      true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 6, Type: "flood", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/6",
+		},
+		{
+			name: "OK tiers",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"tiers": [{"level": "warning", "radius_meters": 2000}, {"level": "danger", "radius_meters": 500}],
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:      "fire",
+				Latitude:  55.751244,
+				Longitude: 37.618423,
+				Tiers: models.Tiers{
+					{Level: models.TierWarning, RadiusMeters: 2000},
+					{Level: models.TierDanger, RadiusMeters: 500},
+				},
+				Active: true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 7, Type: "fire", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/7",
+		},
+		{
+			name: "Tiers not widening",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"tiers": [{"level": "danger", "radius_meters": 2000}, {"level": "info", "radius_meters": 500}]
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Radius does not match tiers",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": 100,
+				"tiers": [{"level": "danger", "radius_meters": 500}]
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Exclusion without radius",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": 2000,
+				"exclusions": [{"name": "Метро", "latitude": 55.75, "longitude": 37.62}]
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "OK line",
+			inputBody: `{
+				"type": "road_closure",
+				"geometry": {"type": "LineString", "coordinates": [[37.6, 55.7], [37.7, 55.7]]},
+				"buffer_meters": 30,
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:         "road_closure",
+				Geometry:     json.RawMessage(`{"type": "LineString", "coordinates": [[37.6, 55.7], [37.7, 55.7]]}`),
+				BufferMeters: 30,
+				Active:       true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 9, Type: "road_closure", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/9",
+		},
+		{
+			name: "Line without buffer",
+			inputBody: `{
+				"type": "road_closure",
+				"geometry": {"type": "LineString", "coordinates": [[37.6, 55.7], [37.7, 55.7]]}
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "OK radius schedule",
+			inputBody: `{
+				"type": "gas_leak",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_schedule": [
+					{"offset_seconds": 0, "radius_meters": 200},
+					{"offset_seconds": 1800, "radius_meters": 2000},
+					{"offset_seconds": 7200, "radius_meters": 500}
+				],
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:      "gas_leak",
+				Latitude:  55.75,
+				Longitude: 37.61,
+				RadiusSchedule: models.RadiusSchedule{
+					{OffsetSeconds: 0, RadiusMeters: 200},
+					{OffsetSeconds: 1800, RadiusMeters: 2000},
+					{OffsetSeconds: 7200, RadiusMeters: 500},
+				},
+				Active: true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 11, Type: "gas_leak", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/11",
+		},
+		{
+			name: "Radius schedule not starting at zero",
+			inputBody: `{
+				"type": "gas_leak",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_schedule": [
+					{"offset_seconds": 60, "radius_meters": 200},
+					{"offset_seconds": 1800, "radius_meters": 2000}
+				]
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Recurring without start",
+			inputBody: `{
+				"type": "street_market",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_meters": 150,
+				"recurrence": {"rrule": "FREQ=WEEKLY;BYDAY=SA", "duration_seconds": 14400}
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unsupported rrule",
+			inputBody: `{
+				"type": "street_market",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_meters": 150,
+				"starts_at": "2026-10-03T07:00:00Z",
+				"recurrence": {"rrule": "FREQ=HOURLY", "duration_seconds": 600}
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Rrule without occurrences",
+			inputBody: `{
+				"type": "street_market",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_meters": 150,
+				"starts_at": "2026-10-03T07:00:00Z",
+				"recurrence": {"rrule": "FREQ=DAILY;INTERVAL=7;BYDAY=MO", "duration_seconds": 600}
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "OK boundaries",
+			inputBody: `{
+				"type": "flood",
+				"boundary_ids": [12, 14],
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:        "flood",
+				BoundaryIDs: models.BoundaryIDs{12, 14},
+				Active:      true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{ID: 10, Type: "flood", Version: 1}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/api/v1/incidents/10",
+		},
+		{
+			name: "Boundaries with geometry",
+			inputBody: `{
+				"type": "flood",
+				"boundary_ids": [12],
+				"geometry": {"type": "Polygon", "coordinates": [[[37.6, 55.7], [37.7, 55.7], [37.7, 55.8], [37.6, 55.7]]]}
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unclosed polygon",
+			inputBody: `{
+				"type": "flood",
+				"geometry": {"type": "Polygon", "coordinates": [[[37.6, 55.7], [37.7, 55.7], [37.7, 55.8], [37.6, 55.8]]]},
+				"active": true
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Missing radius",
+			inputBody: `{
+				"type": "danger",
+				"latitude": 55.751244,
+				"longitude": 37.618423
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:      "danger",
+				Latitude:  55.751244,
+				Longitude: 37.618423,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{}, fmt.Errorf("%w: radius_meters is required, type \"danger\" has no default radius", models.ErrValidation))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Negative radius",
+			inputBody: `{
+				"type": "danger",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": -5
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Window ends before start",
+			inputBody: `{
+				"type": "danger",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": 100,
+				"starts_at": "2025-01-02T00:00:00Z",
+				"ends_at": "2025-01-01T00:00:00Z"
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown severity",
+			inputBody: `{
+				"type": "danger",
+				"severity": "apocalyptic",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": 100
+			}`,
+			mockBehavior:       func(s *mock_service.MockIncident, req models.IncidentRequest) {},
+			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:               "Invalid JSON",
@@ -82,11 +374,33 @@ func TestHandler_CreateIncident(t *testing.T) {
 				Active:       true,
 			},
 			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
-				s.EXPECT().CreateIncident(req).
-					Return(errors.New("service error"))
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{}, errors.New("service error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Unknown type",
+			inputBody: `{
+				"type": "Fire",
+				"latitude": 55.751244,
+				"longitude": 37.618423,
+				"radius_meters": 100,
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:         "Fire",
+				Latitude:     55.751244,
+				Longitude:    37.618423,
+				RadiusMeters: 100,
+				Active:       true,
+			},
+			mockBehavior: func(s *mock_service.MockIncident, req models.IncidentRequest) {
+				s.EXPECT().CreateIncident(req, gomock.Any(), false).
+					Return(models.IncidentResponse{}, fmt.Errorf("%w: unknown incident type %q", models.ErrValidation, "Fire"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -109,6 +423,12 @@ func TestHandler_CreateIncident(t *testing.T) {
 			r.ServeHTTP(w, req)
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedLocation, w.Header().Get("Location"))
+			if testCase.expectedLocation != "" {
+				var created models.IncidentResponse
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+				assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+			}
 		})
 	}
 }
@@ -132,6 +452,7 @@ func TestHandler_GetIncident(t *testing.T) {
 				s.EXPECT().
 					GetIncidentById(id).
 					Return(models.IncidentResponse{
+						ID:           2,
 						Type:         "danger",
 						Description:  "desc_inc_4",
 						Latitude:     55.751244,
@@ -145,6 +466,7 @@ func TestHandler_GetIncident(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedResponseBody: func() string {
 				body, _ := json.Marshal(models.IncidentResponse{
+					ID:           2,
 					Type:         "danger",
 					Description:  "desc_inc_4",
 					Latitude:     55.751244,
@@ -157,6 +479,15 @@ func TestHandler_GetIncident(t *testing.T) {
 				return string(body)
 			}(),
 		},
+		{
+			name: "Not found",
+			id:   9,
+			mockBehavior: func(s *mock_service.MockIncident, id int) {
+				s.EXPECT().GetIncidentById(id).Return(models.IncidentResponse{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"errors":"Инцидент не найден"}`,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -213,8 +544,8 @@ func TestHandler_ListIncidents(t *testing.T) {
 			offset: 10,
 			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
 				s.EXPECT().
-					GetAllIncidents(limit, offset).
-					Return([]models.IncidentResponse{
+					GetAllIncidents(models.IncidentFilter{Limit: limit, Offset: offset, Sort: models.SortCreatedDesc}).
+					Return(models.IncidentPage{Items: []models.IncidentResponse{
 						{
 							Type:         "danger",
 							Description:  "desc1",
@@ -235,11 +566,11 @@ func TestHandler_ListIncidents(t *testing.T) {
 							CreatedAt:    fixedTime,
 							UpdatedAt:    fixedTime,
 						},
-					}, nil)
+					}, HasMore: true, NextCursor: "abc"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponseBody: func() string {
-				body, _ := json.Marshal([]models.IncidentResponse{
+				body, _ := json.Marshal(models.IncidentPage{Items: []models.IncidentResponse{
 					{
 						Type:         "danger",
 						Description:  "desc1",
@@ -260,7 +591,7 @@ func TestHandler_ListIncidents(t *testing.T) {
 						CreatedAt:    fixedTime,
 						UpdatedAt:    fixedTime,
 					},
-				})
+				}, HasMore: true, NextCursor: "abc"})
 				return string(body)
 			}(),
 		},
@@ -271,11 +602,11 @@ func TestHandler_ListIncidents(t *testing.T) {
 			offset: 0,
 			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
 				s.EXPECT().
-					GetAllIncidents(limit, offset).
-					Return([]models.IncidentResponse{}, nil)
+					GetAllIncidents(models.IncidentFilter{Limit: limit, Offset: offset, Sort: models.SortCreatedDesc}).
+					Return(models.IncidentPage{Items: []models.IncidentResponse{}}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[]`,
+			expectedResponseBody: `{"items":[],"has_more":false}`,
 		},
 		{
 			name:   "Invalid params",
@@ -284,11 +615,86 @@ func TestHandler_ListIncidents(t *testing.T) {
 			offset: 0,
 			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
 				s.EXPECT().
-					GetAllIncidents(limit, offset).
-					Return([]models.IncidentResponse{}, nil)
+					GetAllIncidents(models.IncidentFilter{Limit: limit, Offset: offset, Sort: models.SortCreatedDesc}).
+					Return(models.IncidentPage{Items: []models.IncidentResponse{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"items":[],"has_more":false}`,
+		},
+		{
+			name:   "Filters",
+			query:  "?bbox=37.5,55.6,37.8,55.9&type=fire,flood&active=true&lat=55.75&lon=37.61&within=500&sort=distance",
+			limit:  10,
+			offset: 0,
+			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
+				active := true
+				within := 500.0
+				s.EXPECT().
+					GetAllIncidents(models.IncidentFilter{
+						Limit:        limit,
+						Offset:       offset,
+						BBox:         &models.BBox{MinLon: 37.5, MinLat: 55.6, MaxLon: 37.8, MaxLat: 55.9},
+						Point:        &models.GeoPoint{Lat: 55.75, Lon: 37.61},
+						WithinMeters: &within,
+						Types:        []string{"fire", "flood"},
+						Active:       &active,
+						Sort:         models.SortDistance,
+					}).
+					Return(models.IncidentPage{Items: []models.IncidentResponse{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"items":[],"has_more":false}`,
+		},
+		{
+			name:   "Cursor with total",
+			query:  "?limit=5&include_total=true&cursor=" + models.Cursor{Sort: models.SortCreatedDesc, Time: fixedTime, ID: 42}.Encode(),
+			limit:  5,
+			offset: 0,
+			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
+				total := int64(0)
+				s.EXPECT().
+					GetAllIncidents(models.IncidentFilter{
+						Limit:     limit,
+						Offset:    offset,
+						Sort:      models.SortCreatedDesc,
+						After:     &models.Cursor{Sort: models.SortCreatedDesc, Time: fixedTime, ID: 42},
+						WithTotal: true,
+					}).
+					Return(models.IncidentPage{Items: []models.IncidentResponse{}, Total: &total}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[]`,
+			expectedResponseBody: `{"items":[],"has_more":false,"total":0}`,
+		},
+		{
+			name:               "Malformed cursor",
+			query:              "?cursor=not-a-cursor",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Cursor for another sort",
+			query:              "?sort=created_at&cursor=" + models.Cursor{Sort: models.SortCreatedDesc, Time: fixedTime, ID: 42}.Encode(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:                 "Cursor for distance sort",
+			query:                "?sort=distance&lat=55.75&lon=37.61&cursor=" + models.Cursor{Sort: models.SortDistance, Time: fixedTime, ID: 42}.Encode(),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"errors":"sort \"distance\" does not support cursor pagination"}`,
+		},
+		{
+			name:               "Invalid bbox",
+			query:              "?bbox=1,2,3",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Distance sort without point",
+			query:              "?sort=distance",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid time range",
+			query:              "?created_from=yesterday",
+			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:   "Service error",
@@ -297,8 +703,8 @@ func TestHandler_ListIncidents(t *testing.T) {
 			offset: 0,
 			mockBehavior: func(s *mock_service.MockIncident, limit, offset int) {
 				s.EXPECT().
-					GetAllIncidents(limit, offset).
-					Return(nil, errors.New("db error"))
+					GetAllIncidents(models.IncidentFilter{Limit: limit, Offset: offset, Sort: models.SortCreatedDesc}).
+					Return(models.IncidentPage{}, errors.New("db error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -386,7 +792,7 @@ func TestHandler_UpdateIncident(t *testing.T) {
 				req models.IncidentRequest,
 			) {
 				s.EXPECT().
-					UpdateIncident(id, req).
+					UpdateIncident(id, req, gomock.Any()).
 					Return(models.IncidentResponse{
 						Type:         req.Type,
 						Description:  req.Description,
@@ -430,7 +836,7 @@ func TestHandler_UpdateIncident(t *testing.T) {
 			id:   "1",
 			inputBody: `{
 				"type": "danger",
-				"radius_meters": 0
+				"radius_meters": -5
 			}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
@@ -459,11 +865,67 @@ func TestHandler_UpdateIncident(t *testing.T) {
 				req models.IncidentRequest,
 			) {
 				s.EXPECT().
-					UpdateIncident(id, req).
+					UpdateIncident(id, req, gomock.Any()).
 					Return(models.IncidentResponse{}, errors.New("update error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Published incident by operator",
+			id:   "4",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_meters": 5000,
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:         "fire",
+				Latitude:     55.75,
+				Longitude:    37.61,
+				RadiusMeters: 5000,
+				Active:       true,
+			},
+			mockBehavior: func(
+				s *mock_service.MockIncident,
+				id int,
+				req models.IncidentRequest,
+			) {
+				s.EXPECT().
+					UpdateIncident(id, req, gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "Not found",
+			id:   "9",
+			inputBody: `{
+				"type": "fire",
+				"latitude": 55.75,
+				"longitude": 37.61,
+				"radius_meters": 500,
+				"active": true
+			}`,
+			inputReq: models.IncidentRequest{
+				Type:         "fire",
+				Latitude:     55.75,
+				Longitude:    37.61,
+				RadiusMeters: 500,
+				Active:       true,
+			},
+			mockBehavior: func(
+				s *mock_service.MockIncident,
+				id int,
+				req models.IncidentRequest,
+			) {
+				s.EXPECT().
+					UpdateIncident(id, req, gomock.Any()).
+					Return(models.IncidentResponse{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -515,9 +977,9 @@ func TestHandler_DeleteIncident(t *testing.T) {
 			name: "OK",
 			id:   "2",
 			mockBehavior: func(s *mock_service.MockIncident, id int) {
-				s.EXPECT().DeleteIncident(id).Return(nil)
+				s.EXPECT().DeleteIncident(id, gomock.Any()).Return(nil)
 			},
-			expectedStatusCode: http.StatusOK,
+			expectedStatusCode: http.StatusNoContent,
 		},
 		{
 			name: "Invalid ID",
@@ -531,10 +993,26 @@ func TestHandler_DeleteIncident(t *testing.T) {
 			name: "Service Error",
 			id:   "3",
 			mockBehavior: func(s *mock_service.MockIncident, id int) {
-				s.EXPECT().DeleteIncident(id).Return(errors.New("delete error"))
+				s.EXPECT().DeleteIncident(id, gomock.Any()).Return(errors.New("delete error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Published incident by operator",
+			id:   "4",
+			mockBehavior: func(s *mock_service.MockIncident, id int) {
+				s.EXPECT().DeleteIncident(id, gomock.Any()).Return(models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "Not found",
+			id:   "9",
+			mockBehavior: func(s *mock_service.MockIncident, id int) {
+				s.EXPECT().DeleteIncident(id, gomock.Any()).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 	}
 
 	for _, testCase := range testTable {
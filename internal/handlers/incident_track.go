@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// AddTrackPoints принимает новые положения движущегося инцидента.
+func (h *Handler) AddTrackPoints(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.TrackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	err = h.services.AddTrackPoints(r.Context(), id, req, changeMeta(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrValidation):
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrTrackUnsupported):
+			common.WriteErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, models.ErrReviewedIncidentEdit):
+			common.WriteErrorResponse(w, http.StatusForbidden, "Согласованный инцидент может изменить только администратор")
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось сохранить трек")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetIncidentTrack отдаёт трек инцидента как GeoJSON LineString.
+func (h *Handler) GetIncidentTrack(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	points, err := h.services.GetIncidentTrack(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить трек")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	json.NewEncoder(w).Encode(models.NewTrackFeature(int64(id), points))
+}
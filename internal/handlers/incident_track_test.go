@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_IncidentTrack(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	t1 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(5 * time.Minute)
+
+	testTable := []struct {
+		name                 string
+		method               string
+		id                   string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Add points",
+			method: http.MethodPost,
+			id:     "3",
+			body:   `{"points":[{"latitude":55.75,"longitude":37.61,"recorded_at":"2025-01-01T12:00:00Z"}]}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					AddTrackPoints(gomock.Any(), 3, models.TrackRequest{Points: []models.TrackPoint{
+						{Latitude: 55.75, Longitude: 37.61, RecordedAt: t1},
+					}}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "Polygon incident",
+			method: http.MethodPost,
+			id:     "4",
+			body:   `{"points":[{"latitude":55.75,"longitude":37.61,"recorded_at":"2025-01-01T12:00:00Z"}]}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().AddTrackPoints(gomock.Any(), 4, gomock.Any(), gomock.Any()).Return(models.ErrTrackUnsupported)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Published incident by operator",
+			method: http.MethodPost,
+			id:     "5",
+			body:   `{"points":[{"latitude":55.75,"longitude":37.61,"recorded_at":"2025-01-01T12:00:00Z"}]}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().AddTrackPoints(gomock.Any(), 5, gomock.Any(), gomock.Any()).Return(models.ErrReviewedIncidentEdit)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Invalid body",
+			method:             http.MethodPost,
+			id:                 "3",
+			body:               `{"points":`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Get track",
+			method: http.MethodGet,
+			id:     "3",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentTrack(gomock.Any(), 3).Return([]models.TrackPoint{
+					{Latitude: 55.75, Longitude: 37.61, RecordedAt: t1},
+					{Latitude: 55.76, Longitude: 37.62, RecordedAt: t2},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"Feature",
+				"geometry":{"type":"LineString","coordinates":[[37.61,55.75],[37.62,55.76]]},
+				"properties":{"incident_id":3,"timestamps":["2025-01-01T12:00:00Z","2025-01-01T12:05:00Z"]}}`,
+		},
+		{
+			name:   "Get single point track",
+			method: http.MethodGet,
+			id:     "3",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentTrack(gomock.Any(), 3).Return([]models.TrackPoint{
+					{Latitude: 55.75, Longitude: 37.61, RecordedAt: t1},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"Feature","geometry":null,"properties":{"incident_id":3,"timestamps":["2025-01-01T12:00:00Z"]}}`,
+		},
+		{
+			name:   "Get unknown incident",
+			method: http.MethodGet,
+			id:     "9",
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().GetIncidentTrack(gomock.Any(), 9).Return(nil, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(incident)
+			}
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/incidents/{id}/track", handler.AddTrackPoints)
+			r.Get("/api/v1/incidents/{id}/track", handler.GetIncidentTrack)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/api/v1/incidents/"+testCase.id+"/track", bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.JSONEq(t, testCase.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// TransitionIncident переводит инцидент в другое состояние согласования.
+// Допустимость перехода зависит от роли оператора.
+func (h *Handler) TransitionIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req models.TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	meta, ok := conditionalMeta(w, r)
+	if !ok {
+		return
+	}
+
+	incident, err := h.services.TransitionIncident(r.Context(), id, req, meta)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrValidation):
+			common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+		case errors.Is(err, models.ErrTransitionForbidden):
+			common.WriteErrorResponse(w, http.StatusForbidden, "Переход недоступен для вашей роли")
+		case errors.Is(err, models.ErrTransitionNotAllowed):
+			common.WriteErrorResponse(w, http.StatusConflict, "Переход из текущего состояния инцидента невозможен")
+		case errors.Is(err, models.ErrVersionConflict):
+			writePreconditionFailed(w)
+		default:
+			common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось изменить состояние инцидента")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", incidentETag(incident.Version))
+	json.NewEncoder(w).Encode(incident)
+}
+
+func (h *Handler) GetIncidentTransitions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	transitions, err := h.services.GetIncidentTransitions(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			common.WriteErrorResponse(w, http.StatusNotFound, "Инцидент не найден")
+			return
+		}
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить переходы инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transitions)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/handlers/middleware"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_TransitionIncident(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncident)
+
+	testTable := []struct {
+		name               string
+		apiKey             string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:   "Submit for review",
+			apiKey: "alice-key",
+			body:   `{"to":"pending_review"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					TransitionIncident(gomock.Any(), 4,
+						models.TransitionRequest{To: models.StatusPendingReview},
+						models.ChangeMeta{Operator: "alice", Role: middleware.RoleOperator},
+					).
+					Return(models.IncidentResponse{ID: 4, Status: models.StatusPendingReview, Version: 2}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Publish with comment",
+			apiKey: "root-key",
+			body:   `{"to":"published","comment":"Проверено по данным МЧС"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					TransitionIncident(gomock.Any(), 4,
+						models.TransitionRequest{To: models.StatusPublished, Comment: "Проверено по данным МЧС"},
+						models.ChangeMeta{Operator: "root", Role: middleware.RoleAdmin},
+					).
+					Return(models.IncidentResponse{ID: 4, Status: models.StatusPublished, Version: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Operator cannot publish",
+			apiKey: "alice-key",
+			body:   `{"to":"published"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					TransitionIncident(gomock.Any(), 4, gomock.Any(), models.ChangeMeta{Operator: "alice", Role: middleware.RoleOperator}).
+					Return(models.IncidentResponse{}, models.ErrTransitionForbidden)
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:   "Skipping review",
+			apiKey: "root-key",
+			body:   `{"to":"archived"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					TransitionIncident(gomock.Any(), 4, gomock.Any(), gomock.Any()).
+					Return(models.IncidentResponse{}, models.ErrTransitionNotAllowed)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Not found",
+			apiKey: "root-key",
+			body:   `{"to":"resolved"}`,
+			mockBehavior: func(s *mock_service.MockIncident) {
+				s.EXPECT().
+					TransitionIncident(gomock.Any(), 4, gomock.Any(), gomock.Any()).
+					Return(models.IncidentResponse{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid body",
+			apiKey:             "alice-key",
+			body:               `{"to":`,
+			mockBehavior:       func(s *mock_service.MockIncident) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	t.Setenv("OPERATOR_KEYS", "alice:alice-key,root:root-key:admin")
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incident := mock_service.NewMockIncident(ctrl)
+			testCase.mockBehavior(incident)
+
+			handler := NewHandler(&services.Service{Incident: incident})
+
+			r := chi.NewRouter()
+			r.With(middleware.APIKeyAuth).Post("/api/v1/incidents/{id}/transitions", handler.TransitionIncident)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/incidents/4/transitions", bytes.NewBufferString(testCase.body))
+			req.Header.Set("API-Key", testCase.apiKey)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/common"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+func (h *Handler) ListIncidentTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.services.ListIncidentTypes(r.Context())
+	if err != nil {
+		common.WriteErrorResponse(w, http.StatusInternalServerError, "Не удалось получить типы инцидентов")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(types)
+}
+
+func (h *Handler) GetIncidentType(w http.ResponseWriter, r *http.Request) {
+	t, err := h.services.GetIncidentType(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		writeIncidentTypeError(w, err, "Не удалось получить тип инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+func (h *Handler) CreateIncidentType(w http.ResponseWriter, r *http.Request) {
+	var t models.IncidentType
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+
+	created, err := h.services.CreateIncidentType(r.Context(), t)
+	if err != nil {
+		writeIncidentTypeError(w, err, "Не удалось добавить тип инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// UpdateIncidentType заменяет запись справочника; код берётся из пути и не меняется.
+func (h *Handler) UpdateIncidentType(w http.ResponseWriter, r *http.Request) {
+	var t models.IncidentType
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		common.WriteErrorResponse(w, http.StatusBadRequest, "Неверный запрос")
+		return
+	}
+	t.Code = chi.URLParam(r, "code")
+
+	updated, err := h.services.UpdateIncidentType(r.Context(), t)
+	if err != nil {
+		writeIncidentTypeError(w, err, "Не удалось изменить тип инцидента")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (h *Handler) DeleteIncidentType(w http.ResponseWriter, r *http.Request) {
+	err := h.services.DeleteIncidentType(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		writeIncidentTypeError(w, err, "Не удалось удалить тип инцидента")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeIncidentTypeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		common.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		common.WriteErrorResponse(w, http.StatusNotFound, "Тип инцидента не найден")
+	case errors.Is(err, models.ErrIncidentTypeExists):
+		common.WriteErrorResponse(w, http.StatusConflict, "Тип инцидента с таким кодом уже существует")
+	case errors.Is(err, models.ErrIncidentTypeInUse):
+		common.WriteErrorResponse(w, http.StatusConflict, "Тип используется инцидентами")
+	default:
+		common.WriteErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+	mock_service "github.com/rusinadaria/geo-notification-system/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandler_IncidentTypes(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIncidentType)
+
+	fire := models.IncidentType{
+		Code:                "fire",
+		Names:               models.LocalizedNames{"ru": "Пожар", "en": "Fire"},
+		DefaultRadiusMeters: 300,
+		DefaultSeverity:     models.SeverityDanger,
+		Icon:                "flame",
+	}
+
+	testTable := []struct {
+		name               string
+		method             string
+		path               string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-types/",
+			body:   `{"code":"fire","names":{"ru":"Пожар","en":"Fire"},"default_radius_meters":300,"default_severity":"danger","icon":"flame"}`,
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().CreateIncidentType(gomock.Any(), fire).Return(fire, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "Create invalid",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-types/",
+			body:   `{"code":"Fire","names":{"ru":"Пожар"}}`,
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().CreateIncidentType(gomock.Any(), gomock.Any()).
+					Return(models.IncidentType{}, fmt.Errorf("%w: code must be lowercase", models.ErrValidation))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Create duplicate",
+			method: http.MethodPost,
+			path:   "/api/v1/incident-types/",
+			body:   `{"code":"fire","names":{"ru":"Пожар"}}`,
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().CreateIncidentType(gomock.Any(), gomock.Any()).
+					Return(models.IncidentType{}, models.ErrIncidentTypeExists)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "Update takes code from path",
+			method: http.MethodPut,
+			path:   "/api/v1/incident-types/fire",
+			body:   `{"code":"flood","names":{"ru":"Пожар","en":"Fire"},"default_radius_meters":300,"default_severity":"danger","icon":"flame"}`,
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().UpdateIncidentType(gomock.Any(), fire).Return(fire, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "Get unknown",
+			method: http.MethodGet,
+			path:   "/api/v1/incident-types/smoke",
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().GetIncidentType(gomock.Any(), "smoke").Return(models.IncidentType{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Delete in use",
+			method: http.MethodDelete,
+			path:   "/api/v1/incident-types/fire",
+			mockBehavior: func(s *mock_service.MockIncidentType) {
+				s.EXPECT().DeleteIncidentType(gomock.Any(), "fire").Return(models.ErrIncidentTypeInUse)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			incidentType := mock_service.NewMockIncidentType(ctrl)
+			testCase.mockBehavior(incidentType)
+
+			handler := NewHandler(&services.Service{IncidentType: incidentType})
+
+			r := chi.NewRouter()
+			r.Route("/api/v1/incident-types", func(r chi.Router) {
+				r.Post("/", handler.CreateIncidentType)
+				r.Get("/{code}", handler.GetIncidentType)
+				r.Put("/{code}", handler.UpdateIncidentType)
+				r.Delete("/{code}", handler.DeleteIncidentType)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
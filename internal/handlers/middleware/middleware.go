@@ -1,20 +1,87 @@
 package middleware
 
 import (
+	"context"
+	"crypto/subtle"
 	"net/http"
 	"os"
+	"strings"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type operatorCtxKey struct{}
+
+type roleCtxKey struct{}
+
+// DefaultOperator — имя, под которым действует владелец общего ключа OPERATOR_API_KEY.
+const DefaultOperator = "operator"
+
+// Роли операторов. Роль по умолчанию — RoleOperator.
+const (
+	RoleOperator = models.RoleOperator
+	RoleAdmin    = models.RoleAdmin
 )
 
 func APIKeyAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiKey := r.Header.Get("API-Key")
-		expected := os.Getenv("OPERATOR_API_KEY")
 
-		if apiKey == "" || apiKey != expected {
+		operator, role, ok := operatorByKey(apiKey)
+		if apiKey == "" || !ok {
 			http.Error(w, "unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		ctx := context.WithValue(r.Context(), operatorCtxKey{}, operator)
+		ctx = context.WithValue(ctx, roleCtxKey{}, role)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// OperatorFromContext возвращает имя оператора, прошедшего APIKeyAuth.
+func OperatorFromContext(ctx context.Context) string {
+	operator, _ := ctx.Value(operatorCtxKey{}).(string)
+	return operator
+}
+
+// RoleFromContext возвращает роль оператора, прошедшего APIKeyAuth.
+func RoleFromContext(ctx context.Context) string {
+	role, _ := ctx.Value(roleCtxKey{}).(string)
+	return role
+}
+
+// operatorByKey ищет оператора по ключу. Персональные ключи задаются в
+// OPERATOR_KEYS в виде "имя:ключ[:роль],имя:ключ[:роль]"; общий
+// OPERATOR_API_KEY соответствует оператору DefaultOperator.
+func operatorByKey(apiKey string) (name, role string, ok bool) {
+	if keyEqual(apiKey, os.Getenv("OPERATOR_API_KEY")) {
+		return DefaultOperator, RoleOperator, true
+	}
+
+	for _, entry := range strings.Split(os.Getenv("OPERATOR_KEYS"), ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || name == "" {
+			continue
+		}
+
+		role := RoleOperator
+		if i := strings.LastIndex(key, ":"); i >= 0 && knownRole(key[i+1:]) {
+			key, role = key[:i], key[i+1:]
+		}
+
+		if keyEqual(apiKey, key) {
+			return name, role, true
+		}
+	}
+
+	return "", "", false
+}
+
+func knownRole(role string) bool {
+	return role == RoleOperator || role == RoleAdmin
+}
+
+func keyEqual(got, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(got), []byte(expected)) == 1
+}
@@ -0,0 +1,56 @@
+package incidentio
+
+import (
+	"encoding/json"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type boundaryFeature struct {
+	Type       string          `json:"type"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties struct {
+		Code       string               `json:"code"`
+		Name       string               `json:"name"`
+		Level      models.BoundaryLevel `json:"level"`
+		ParentCode string               `json:"parent_code"`
+	} `json:"properties"`
+}
+
+// ParseBoundaries читает FeatureCollection административных границ. В отличие
+// от импорта инцидентов файл принимается только целиком: ошибка любой Feature
+// — ошибка всего файла.
+func ParseBoundaries(data []byte) ([]models.BoundaryImport, error) {
+	var collection struct {
+		Type     string            `json:"type"`
+		Features []boundaryFeature `json:"features"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, invalidFile("%v", err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) == 0 {
+		return nil, invalidFile("expected a GeoJSON FeatureCollection with features")
+	}
+
+	boundaries := make([]models.BoundaryImport, 0, len(collection.Features))
+	for i, f := range collection.Features {
+		if f.Type != "Feature" {
+			return nil, invalidFile("features[%d]: expected a Feature, got %q", i, f.Type)
+		}
+
+		b := models.BoundaryImport{
+			Code:       f.Properties.Code,
+			Name:       f.Properties.Name,
+			Level:      f.Properties.Level,
+			ParentCode: f.Properties.ParentCode,
+			Geometry:   f.Geometry,
+		}
+		if err := b.Validate(); err != nil {
+			return nil, invalidFile("features[%d]: %v", i, err)
+		}
+
+		boundaries = append(boundaries, b)
+	}
+
+	return boundaries, nil
+}
@@ -0,0 +1,313 @@
+package incidentio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/geo"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const FormatKML = "kml"
+
+// Writer потоково записывает инциденты в файл экспорта: Begin, затем Write
+// для каждого инцидента и End в конце.
+type Writer interface {
+	ContentType() string
+	Extension() string
+	Begin() error
+	Write(inc models.IncidentResponse) error
+	End() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatGeoJSON:
+		return &geoJSONWriter{w: w}, nil
+	case FormatKML:
+		return &kmlWriter{w: w, enc: xml.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type exportProperties struct {
+	ID             int64                 `json:"id"`
+	Type           string                `json:"type"`
+	Severity       models.Severity       `json:"severity"`
+	Description    string                `json:"description,omitempty"`
+	RadiusMeters   int                   `json:"radius_meters,omitempty"`
+	RadiusSchedule models.RadiusSchedule `json:"radius_schedule,omitempty"`
+	BufferMeters   int                   `json:"buffer_meters,omitempty"`
+	Tiers          models.Tiers          `json:"tiers,omitempty"`
+	Exclusions     models.Exclusions     `json:"exclusions,omitempty"`
+	Active         bool                  `json:"active"`
+	Live           bool                  `json:"live"`
+	StartsAt       *time.Time            `json:"starts_at,omitempty"`
+	EndsAt         *time.Time            `json:"ends_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+func newExportProperties(inc models.IncidentResponse) exportProperties {
+	return exportProperties{
+		ID:             inc.ID,
+		Type:           inc.Type,
+		Severity:       inc.Severity,
+		Description:    inc.Description,
+		RadiusMeters:   inc.RadiusMeters,
+		RadiusSchedule: inc.RadiusSchedule,
+		BufferMeters:   inc.BufferMeters,
+		Tiers:          inc.Tiers,
+		Exclusions:     inc.Exclusions,
+		Active:         inc.Active,
+		Live:           inc.Live,
+		StartsAt:       inc.StartsAt,
+		EndsAt:         inc.EndsAt,
+		CreatedAt:      inc.CreatedAt,
+		UpdatedAt:      inc.UpdatedAt,
+	}
+}
+
+type geoJSONWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (g *geoJSONWriter) ContentType() string { return "application/geo+json" }
+func (g *geoJSONWriter) Extension() string   { return "geojson" }
+
+func (g *geoJSONWriter) Begin() error {
+	_, err := io.WriteString(g.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (g *geoJSONWriter) Write(inc models.IncidentResponse) error {
+	geometry := inc.Geometry
+	if !models.HasGeometry(geometry) {
+		// круговая зона: центр точкой, радиус — в radius_meters
+		geometry = json.RawMessage(fmt.Sprintf(`{"type":"Point","coordinates":[%s,%s]}`,
+			formatFloat(inc.Longitude), formatFloat(inc.Latitude)))
+	}
+
+	data, err := json.Marshal(struct {
+		Type       string           `json:"type"`
+		ID         int64            `json:"id"`
+		Geometry   json.RawMessage  `json:"geometry"`
+		Properties exportProperties `json:"properties"`
+	}{
+		Type:       "Feature",
+		ID:         inc.ID,
+		Geometry:   geometry,
+		Properties: newExportProperties(inc),
+	})
+	if err != nil {
+		return err
+	}
+
+	if g.count > 0 {
+		if _, err := io.WriteString(g.w, ","); err != nil {
+			return err
+		}
+	}
+	g.count++
+
+	_, err = g.w.Write(data)
+	return err
+}
+
+func (g *geoJSONWriter) End() error {
+	_, err := io.WriteString(g.w, "]}\n")
+	return err
+}
+
+type kmlWriter struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+type kmlPlacemark struct {
+	XMLName      xml.Name     `xml:"Placemark"`
+	ID           string       `xml:"id,attr"`
+	Name         string       `xml:"name"`
+	Description  string       `xml:"description,omitempty"`
+	ExtendedData []kmlData    `xml:"ExtendedData>Data"`
+	Geometry     kmlMultiGeom `xml:"MultiGeometry"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlMultiGeom struct {
+	Point       *kmlPoint       `xml:"Point,omitempty"`
+	LineStrings []kmlLineString `xml:"LineString"`
+	Polygons    []kmlPolygon    `xml:"Polygon"`
+}
+
+type kmlLineString struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	Outer string   `xml:"outerBoundaryIs>LinearRing>coordinates"`
+	Inner []string `xml:"innerBoundaryIs>LinearRing>coordinates"`
+}
+
+// количество сегментов, которыми круговая зона рисуется в KML
+const kmlCircleSegments = 64
+
+func (k *kmlWriter) ContentType() string { return "application/vnd.google-earth.kml+xml" }
+func (k *kmlWriter) Extension() string   { return "kml" }
+
+func (k *kmlWriter) Begin() error {
+	_, err := io.WriteString(k.w, xml.Header+
+		`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>Incidents</name>`)
+	return err
+}
+
+func (k *kmlWriter) Write(inc models.IncidentResponse) error {
+	props := newExportProperties(inc)
+
+	placemark := kmlPlacemark{
+		ID:          "incident-" + strconv.FormatInt(inc.ID, 10),
+		Name:        fmt.Sprintf("%s #%d", inc.Type, inc.ID),
+		Description: inc.Description,
+		ExtendedData: []kmlData{
+			{Name: "id", Value: strconv.FormatInt(inc.ID, 10)},
+			{Name: "type", Value: props.Type},
+			{Name: "severity", Value: string(props.Severity)},
+			{Name: "radius_meters", Value: strconv.Itoa(props.RadiusMeters)},
+			{Name: "buffer_meters", Value: strconv.Itoa(props.BufferMeters)},
+			{Name: "active", Value: strconv.FormatBool(props.Active)},
+			{Name: "live", Value: strconv.FormatBool(props.Live)},
+			{Name: "starts_at", Value: formatTime(props.StartsAt)},
+			{Name: "ends_at", Value: formatTime(props.EndsAt)},
+			{Name: "created_at", Value: formatTime(&props.CreatedAt)},
+			{Name: "updated_at", Value: formatTime(&props.UpdatedAt)},
+		},
+		Geometry: kmlMultiGeom{
+			Point: &kmlPoint{Coordinates: kmlCoordinates(geo.Ring{{inc.Longitude, inc.Latitude}})},
+		},
+	}
+
+	if models.IsLine(inc.Geometry) {
+		lines, err := geo.ParseLine(inc.Geometry)
+		if err != nil {
+			return err
+		}
+		for _, l := range lines {
+			placemark.Geometry.LineStrings = append(placemark.Geometry.LineStrings,
+				kmlLineString{Coordinates: kmlCoordinates(geo.Ring(l))})
+		}
+	} else if models.HasGeometry(inc.Geometry) {
+		polygons, err := geo.ParseZone(inc.Geometry)
+		if err != nil {
+			return err
+		}
+		for _, p := range polygons {
+			kp := kmlPolygon{Outer: kmlCoordinates(p[0])}
+			for _, hole := range p[1:] {
+				kp.Inner = append(kp.Inner, kmlCoordinates(hole))
+			}
+			placemark.Geometry.Polygons = append(placemark.Geometry.Polygons, kp)
+		}
+	} else if inc.RadiusMeters > 0 {
+		circle := geo.Circle(inc.Latitude, inc.Longitude, float64(inc.RadiusMeters), kmlCircleSegments)
+		placemark.Geometry.Polygons = []kmlPolygon{{Outer: kmlCoordinates(circle)}}
+	}
+
+	return k.enc.Encode(placemark)
+}
+
+func (k *kmlWriter) End() error {
+	if err := k.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(k.w, "</Document></kml>\n")
+	return err
+}
+
+func kmlCoordinates(ring geo.Ring) string {
+	parts := make([]string, len(ring))
+	for i, pos := range ring {
+		parts[i] = formatFloat(pos[0]) + "," + formatFloat(pos[1])
+	}
+	return strings.Join(parts, " ")
+}
+
+// CSVHeader — колонки экспорта. Их же понимает импорт, служебные id, live,
+// created_at и updated_at при импорте игнорируются.
+var CSVHeader = []string{
+	"id", "type", "severity", "description", "latitude", "longitude", "radius_meters",
+	"geometry", "buffer_meters", "active", "live", "starts_at", "ends_at", "created_at", "updated_at",
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) ContentType() string { return "text/csv; charset=utf-8" }
+func (c *csvWriter) Extension() string   { return "csv" }
+
+func (c *csvWriter) Begin() error {
+	return c.w.Write(CSVHeader)
+}
+
+func (c *csvWriter) Write(inc models.IncidentResponse) error {
+	radius := ""
+	if inc.RadiusMeters > 0 {
+		radius = strconv.Itoa(inc.RadiusMeters)
+	}
+	buffer := ""
+	if inc.BufferMeters > 0 {
+		buffer = strconv.Itoa(inc.BufferMeters)
+	}
+
+	return c.w.Write([]string{
+		strconv.FormatInt(inc.ID, 10),
+		inc.Type,
+		string(inc.Severity),
+		inc.Description,
+		formatFloat(inc.Latitude),
+		formatFloat(inc.Longitude),
+		radius,
+		string(inc.Geometry),
+		buffer,
+		strconv.FormatBool(inc.Active),
+		strconv.FormatBool(inc.Live),
+		formatTime(inc.StartsAt),
+		formatTime(inc.EndsAt),
+		formatTime(&inc.CreatedAt),
+		formatTime(&inc.UpdatedAt),
+	})
+}
+
+func (c *csvWriter) End() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,127 @@
+package incidentio
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportFixture = []models.IncidentResponse{
+	{
+		ID:           1,
+		Type:         "fire",
+		Severity:     models.SeverityCritical,
+		Description:  "forest, north",
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 300,
+		Tiers:        models.Tiers{{Level: models.TierDanger, RadiusMeters: 100}, {Level: models.TierWarning, RadiusMeters: 300}},
+		Active:       true,
+		CreatedAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        2,
+		Type:      "flood",
+		Severity:  models.SeverityWarning,
+		Latitude:  0.5,
+		Longitude: 0.5,
+		Geometry:  json.RawMessage(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`),
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:           3,
+		Type:         "road_closure",
+		Severity:     models.SeverityInfo,
+		Latitude:     55.7,
+		Longitude:    37.65,
+		Geometry:     json.RawMessage(`{"type":"LineString","coordinates":[[37.6,55.7],[37.7,55.7]]}`),
+		BufferMeters: 30,
+		CreatedAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func writeFixture(t *testing.T, format string) []byte {
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Begin())
+	for _, inc := range exportFixture {
+		require.NoError(t, writer.Write(inc))
+	}
+	require.NoError(t, writer.End())
+
+	return buf.Bytes()
+}
+
+func TestGeoJSONWriter(t *testing.T) {
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type string `json:"type"`
+			} `json:"geometry"`
+			Properties exportProperties `json:"properties"`
+		} `json:"features"`
+	}
+
+	require.NoError(t, json.Unmarshal(writeFixture(t, FormatGeoJSON), &collection))
+
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 3)
+	assert.Equal(t, "Point", collection.Features[0].Geometry.Type)
+	assert.Equal(t, int64(1), collection.Features[0].Properties.ID)
+	assert.Equal(t, 300, collection.Features[0].Properties.RadiusMeters)
+	assert.Equal(t, exportFixture[0].Tiers, collection.Features[0].Properties.Tiers)
+	assert.Equal(t, "Polygon", collection.Features[1].Geometry.Type)
+	assert.Equal(t, "LineString", collection.Features[2].Geometry.Type)
+	assert.Equal(t, 30, collection.Features[2].Properties.BufferMeters)
+}
+
+func TestKMLWriter(t *testing.T) {
+	var doc struct {
+		Placemarks []struct {
+			Name     string `xml:"name"`
+			Polygons []struct {
+				Outer string `xml:"outerBoundaryIs>LinearRing>coordinates"`
+			} `xml:"MultiGeometry>Polygon"`
+			LineStrings []string `xml:"MultiGeometry>LineString>coordinates"`
+		} `xml:"Document>Placemark"`
+	}
+
+	require.NoError(t, xml.Unmarshal(writeFixture(t, FormatKML), &doc))
+
+	require.Len(t, doc.Placemarks, 3)
+	assert.Equal(t, "fire #1", doc.Placemarks[0].Name)
+	require.Len(t, doc.Placemarks[0].Polygons, 1)
+	require.Len(t, doc.Placemarks[1].Polygons, 1)
+	assert.Equal(t, "0,0 1,0 1,1 0,0", doc.Placemarks[1].Polygons[0].Outer)
+	assert.Equal(t, []string{"37.6,55.7 37.7,55.7"}, doc.Placemarks[2].LineStrings)
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	rows, err := ParseCSV(bytes.NewReader(writeFixture(t, FormatCSV)))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	for _, row := range rows {
+		require.NoError(t, row.Err)
+		assert.NoError(t, row.Request.Validate())
+	}
+
+	assert.Equal(t, "forest, north", rows[0].Request.Description)
+	assert.Equal(t, 300, rows[0].Request.RadiusMeters)
+	assert.JSONEq(t, string(exportFixture[1].Geometry), string(rows[1].Request.Geometry))
+	assert.JSONEq(t, string(exportFixture[2].Geometry), string(rows[2].Request.Geometry))
+	assert.Equal(t, 30, rows[2].Request.BufferMeters)
+}
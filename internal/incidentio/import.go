@@ -0,0 +1,332 @@
+package incidentio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const (
+	FormatGeoJSON = "geojson"
+	FormatCSV     = "csv"
+)
+
+var ErrInvalidFile = errors.New("invalid import file")
+
+type feature struct {
+	Type       string          `json:"type"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties properties      `json:"properties"`
+}
+
+// properties — атрибуты инцидента в GeoJSON Feature; те же имена используются
+// как колонки CSV.
+type properties struct {
+	Type           string                `json:"type"`
+	Severity       models.Severity       `json:"severity,omitempty"`
+	Description    string                `json:"description,omitempty"`
+	RadiusMeters   int                   `json:"radius_meters,omitempty"`
+	RadiusSchedule models.RadiusSchedule `json:"radius_schedule,omitempty"`
+	BufferMeters   int                   `json:"buffer_meters,omitempty"`
+	Tiers          models.Tiers          `json:"tiers,omitempty"`
+	Exclusions     models.Exclusions     `json:"exclusions,omitempty"`
+	Active         *bool                 `json:"active,omitempty"`
+	StartsAt       *time.Time            `json:"starts_at,omitempty"`
+	EndsAt         *time.Time            `json:"ends_at,omitempty"`
+}
+
+// ParseGeoJSON читает FeatureCollection. Ошибки отдельных Feature возвращаются
+// в ImportRow.Err, ошибка всего документа — вторым значением.
+func ParseGeoJSON(data []byte) ([]models.ImportRow, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var (
+		rows        []models.ImportRow
+		collection  bool
+		hasFeatures bool
+	)
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, invalidFile("%v", err)
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "type":
+			var t string
+			if err := dec.Decode(&t); err != nil || t != "FeatureCollection" {
+				return nil, invalidFile("expected a GeoJSON FeatureCollection")
+			}
+			collection = true
+		case "features":
+			hasFeatures = true
+			if err := expectDelim(dec, '['); err != nil {
+				return nil, err
+			}
+			for dec.More() {
+				line := lineAt(data, dec.InputOffset())
+
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
+					return nil, invalidFile("line %d: %v", line, err)
+				}
+
+				req, err := featureRequest(raw)
+				rows = append(rows, models.ImportRow{Line: line, Request: req, Err: err})
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, invalidFile("%v", err)
+			}
+		}
+	}
+
+	if !collection || !hasFeatures {
+		return nil, invalidFile("expected a GeoJSON FeatureCollection with features")
+	}
+
+	return rows, nil
+}
+
+func featureRequest(raw json.RawMessage) (models.IncidentRequest, error) {
+	var f feature
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return models.IncidentRequest{}, err
+	}
+	if f.Type != "Feature" {
+		return models.IncidentRequest{}, fmt.Errorf("expected a Feature, got %q", f.Type)
+	}
+
+	req := f.Properties.request()
+
+	// без геометрии инцидент оказался бы кругом в точке (0, 0)
+	if !models.HasGeometry(f.Geometry) {
+		return models.IncidentRequest{}, fmt.Errorf("%w: feature geometry is required", models.ErrValidation)
+	}
+
+	var g struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(f.Geometry, &g); err != nil {
+		return models.IncidentRequest{}, errors.New("feature geometry must be a GeoJSON object")
+	}
+	if g.Type == "" {
+		return models.IncidentRequest{}, fmt.Errorf("%w: feature geometry type is required", models.ErrValidation)
+	}
+
+	// Point задаёт центр круговой зоны, остальные геометрии передаются как есть
+	// и проверяются общими правилами валидации.
+	if g.Type == "Point" {
+		var pos [2]float64
+		if err := json.Unmarshal(g.Coordinates, &pos); err != nil {
+			return models.IncidentRequest{}, errors.New("point coordinates must be [lon, lat]")
+		}
+		req.Longitude, req.Latitude = pos[0], pos[1]
+	} else {
+		req.Geometry = f.Geometry
+	}
+
+	return req, nil
+}
+
+func (p properties) request() models.IncidentRequest {
+	// в отличие от одиночного создания, импортируемые инциденты по умолчанию включены
+	active := true
+	if p.Active != nil {
+		active = *p.Active
+	}
+
+	return models.IncidentRequest{
+		Type:           p.Type,
+		Severity:       p.Severity,
+		Description:    p.Description,
+		RadiusMeters:   p.RadiusMeters,
+		RadiusSchedule: p.RadiusSchedule,
+		BufferMeters:   p.BufferMeters,
+		Tiers:          p.Tiers,
+		Exclusions:     p.Exclusions,
+		Active:         active,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+	}
+}
+
+var csvColumns = map[string]bool{
+	"type":          true,
+	"severity":      true,
+	"description":   true,
+	"latitude":      true,
+	"longitude":     true,
+	"radius_meters": true,
+	"geometry":      true,
+	"buffer_meters": true,
+	"active":        true,
+	"starts_at":     true,
+	"ends_at":       true,
+	// колонки экспорта, которые при импорте игнорируются
+	"id":         false,
+	"live":       false,
+	"created_at": false,
+	"updated_at": false,
+}
+
+// ParseCSV читает CSV с заголовком. Колонка geometry, если есть, содержит
+// GeoJSON-геометрию; пустые значения означают «не задано».
+func ParseCSV(r io.Reader) ([]models.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, invalidFile("failed to read CSV header: %v", err)
+	}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := csvColumns[name]; !ok {
+			return nil, invalidFile("unknown CSV column %q", name)
+		}
+		header[i] = name
+	}
+
+	var rows []models.ImportRow
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount {
+				rows = append(rows, models.ImportRow{Line: parseErr.StartLine, Err: errors.New("wrong number of fields")})
+				continue
+			}
+			return nil, invalidFile("%v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		fields := make(map[string]string, len(header))
+		for i, name := range header {
+			fields[name] = strings.TrimSpace(record[i])
+		}
+
+		req, err := csvRequest(fields)
+		rows = append(rows, models.ImportRow{Line: line, Request: req, Err: err})
+	}
+
+	return rows, nil
+}
+
+func csvRequest(fields map[string]string) (models.IncidentRequest, error) {
+	p := properties{
+		Type:        fields["type"],
+		Severity:    models.Severity(fields["severity"]),
+		Description: fields["description"],
+	}
+
+	var (
+		req models.IncidentRequest
+		err error
+	)
+
+	if v := fields["radius_meters"]; v != "" {
+		if p.RadiusMeters, err = strconv.Atoi(v); err != nil {
+			return req, errors.New("radius_meters must be an integer")
+		}
+	}
+	if v := fields["buffer_meters"]; v != "" {
+		if p.BufferMeters, err = strconv.Atoi(v); err != nil {
+			return req, errors.New("buffer_meters must be an integer")
+		}
+	}
+	if v := fields["active"]; v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return req, errors.New("active must be true or false")
+		}
+		p.Active = &active
+	}
+	if p.StartsAt, err = parseTime(fields["starts_at"]); err != nil {
+		return req, errors.New("starts_at must be an RFC 3339 timestamp")
+	}
+	if p.EndsAt, err = parseTime(fields["ends_at"]); err != nil {
+		return req, errors.New("ends_at must be an RFC 3339 timestamp")
+	}
+
+	req = p.request()
+
+	if v := fields["geometry"]; v != "" {
+		req.Geometry = json.RawMessage(v)
+	}
+	if v := fields["latitude"]; v != "" {
+		if req.Latitude, err = strconv.ParseFloat(v, 64); err != nil {
+			return req, errors.New("latitude must be a number")
+		}
+	}
+	if v := fields["longitude"]; v != "" {
+		if req.Longitude, err = strconv.ParseFloat(v, 64); err != nil {
+			return req, errors.New("longitude must be a number")
+		}
+	}
+
+	return req, nil
+}
+
+func parseTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return invalidFile("%v", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return invalidFile("unexpected token %v, expected %v", tok, delim)
+	}
+	return nil
+}
+
+// lineAt возвращает номер строки первого значимого символа начиная с offset.
+func lineAt(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[i])) {
+		i++
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+func invalidFile(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFile, fmt.Sprintf(format, args...))
+}
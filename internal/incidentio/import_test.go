@@ -0,0 +1,106 @@
+package incidentio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGeoJSON(t *testing.T) {
+	data := `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {"type": "Point", "coordinates": [37.61, 55.75]},
+      "properties": {"type": "fire", "severity": "critical", "radius_meters": 300}
+    },
+    {
+      "type": "Feature",
+      "geometry": {"type": "Polygon", "coordinates": [[[0,0],[1,0],[1,1],[0,0]]]},
+      "properties": {"type": "flood", "active": false}
+    },
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"radius_meters": "wide"}}
+  ]
+}`
+
+	rows, err := ParseGeoJSON([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 4, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "fire", rows[0].Request.Type)
+	assert.Equal(t, 55.75, rows[0].Request.Latitude)
+	assert.Equal(t, 37.61, rows[0].Request.Longitude)
+	assert.Equal(t, 300, rows[0].Request.RadiusMeters)
+	assert.True(t, rows[0].Request.Active)
+
+	assert.Equal(t, 9, rows[1].Line)
+	assert.NoError(t, rows[1].Err)
+	assert.NotEmpty(t, rows[1].Request.Geometry)
+	assert.False(t, rows[1].Request.Active)
+
+	assert.Equal(t, 14, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+}
+
+func TestParseGeoJSON_NotCollection(t *testing.T) {
+	_, err := ParseGeoJSON([]byte(`{"type": "Feature", "geometry": null, "properties": {}}`))
+	assert.True(t, errors.Is(err, ErrInvalidFile))
+}
+
+func TestParseCSV(t *testing.T) {
+	data := "type,severity,latitude,longitude,radius_meters,active\n" +
+		"fire,danger,55.75,37.61,200,\n" +
+		"flood,warning,1,2,abc,true\n" +
+		"smoke,info,1,2\n"
+
+	rows, err := ParseCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, 200, rows[0].Request.RadiusMeters)
+	assert.True(t, rows[0].Request.Active)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Error(t, rows[1].Err)
+
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+}
+
+func TestParseCSV_UnknownColumn(t *testing.T) {
+	_, err := ParseCSV(strings.NewReader("type,color\nfire,red\n"))
+	assert.True(t, errors.Is(err, ErrInvalidFile))
+}
+
+func TestParseGeoJSON_MissingGeometry(t *testing.T) {
+	testTable := []struct {
+		name     string
+		geometry string
+	}{
+		{name: "Null", geometry: `"geometry": null,`},
+		{name: "Absent", geometry: ``},
+		{name: "Empty type", geometry: `"geometry": {"coordinates": [37.61, 55.75]},`},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", ` + testCase.geometry + ` "properties": {"type": "fire", "radius_meters": 300}}
+			]}`
+
+			rows, err := ParseGeoJSON([]byte(data))
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			assert.ErrorIs(t, rows[0].Err, models.ErrValidation)
+		})
+	}
+}
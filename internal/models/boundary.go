@@ -0,0 +1,160 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rusinadaria/geo-notification-system/internal/geo"
+)
+
+// BoundaryLevel — уровень административной границы.
+type BoundaryLevel string
+
+const (
+	BoundaryRegion   BoundaryLevel = "region"
+	BoundaryCity     BoundaryLevel = "city"
+	BoundaryDistrict BoundaryLevel = "district"
+)
+
+// boundaryDepth — глубина уровня в иерархии регион → город → район.
+var boundaryDepth = map[BoundaryLevel]int{
+	BoundaryRegion:   1,
+	BoundaryCity:     2,
+	BoundaryDistrict: 3,
+}
+
+func (l BoundaryLevel) Valid() bool {
+	_, ok := boundaryDepth[l]
+	return ok
+}
+
+// Contains сообщает, может ли граница уровня l содержать границу уровня child.
+func (l BoundaryLevel) Contains(child BoundaryLevel) bool {
+	return boundaryDepth[l] < boundaryDepth[child]
+}
+
+const (
+	maxBoundaryCodeLen = 64
+	maxBoundaryNameLen = 200
+	maxIncidentBounds  = 100
+)
+
+// Boundary — административная граница. Geometry заполняется только при
+// запросе одной границы.
+type Boundary struct {
+	ID        int64           `json:"id" db:"id"`
+	ParentID  *int64          `json:"parent_id,omitempty" db:"parent_id"`
+	Level     BoundaryLevel   `json:"level" db:"level"`
+	Code      string          `json:"code" db:"code"`
+	Name      string          `json:"name" db:"name"`
+	Geometry  json.RawMessage `json:"geometry,omitempty" db:"geometry"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// BoundaryRef — граница, в которой находится пользователь, в ответе проверки
+// локации и в вебхуке.
+type BoundaryRef struct {
+	ID    int64         `json:"id"`
+	Code  string        `json:"code"`
+	Name  string        `json:"name"`
+	Level BoundaryLevel `json:"level"`
+}
+
+type BoundaryFilter struct {
+	Level    BoundaryLevel
+	ParentID *int64
+}
+
+// BoundaryImport — граница из файла импорта. Родитель задаётся кодом: он
+// может быть уже загружен или находиться в том же файле.
+type BoundaryImport struct {
+	Code       string
+	Name       string
+	Level      BoundaryLevel
+	ParentCode string
+	Geometry   json.RawMessage
+}
+
+type BoundaryImportResult struct {
+	Imported int     `json:"imported"`
+	IDs      []int64 `json:"ids"`
+}
+
+func (b BoundaryImport) Validate() error {
+	if b.Code == "" {
+		return validationError("code is required")
+	}
+	if utf8.RuneCountInString(b.Code) > maxBoundaryCodeLen {
+		return validationError("code must be at most %d characters", maxBoundaryCodeLen)
+	}
+	if b.Name == "" {
+		return validationError("name is required")
+	}
+	if utf8.RuneCountInString(b.Name) > maxBoundaryNameLen {
+		return validationError("name must be at most %d characters", maxBoundaryNameLen)
+	}
+	if !b.Level.Valid() {
+		return validationError("level must be one of region, city, district")
+	}
+	if b.ParentCode == b.Code {
+		return validationError("boundary cannot be its own parent")
+	}
+	if _, err := geo.ParseZone(b.Geometry); err != nil {
+		return validationError("%v", err)
+	}
+
+	return nil
+}
+
+// BoundaryIDs — границы, которыми задана зона инцидента.
+type BoundaryIDs []int64
+
+func (ids *BoundaryIDs) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, ids)
+	case string:
+		return json.Unmarshal([]byte(v), ids)
+	case nil:
+		*ids = nil
+		return nil
+	default:
+		return errors.New("unsupported type for boundary ids")
+	}
+}
+
+// Value отдаёт идентификаторы массивом PostgreSQL.
+func (ids BoundaryIDs) Value() (driver.Value, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal([]int64(ids))
+	if err != nil {
+		return nil, err
+	}
+	// [1,2,3] -> {1,2,3}
+	data[0], data[len(data)-1] = '{', '}'
+	return string(data), nil
+}
+
+func (ids BoundaryIDs) Validate() error {
+	if len(ids) > maxIncidentBounds {
+		return validationError("at most %d boundary_ids are allowed", maxIncidentBounds)
+	}
+
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		if id <= 0 {
+			return validationError("boundary_ids must be positive")
+		}
+		if seen[id] {
+			return validationError("boundary %d is specified more than once", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// DuplicateCandidate — действующий инцидент, похожий на создаваемый.
+// Overlap — доля площади меньшей из двух зон, покрытая их пересечением.
+type DuplicateCandidate struct {
+	ID          int64     `json:"id" db:"id"`
+	Type        string    `json:"type" db:"type"`
+	Severity    Severity  `json:"severity" db:"severity"`
+	Description string    `json:"description" db:"description"`
+	Overlap     float64   `json:"overlap" db:"overlap"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// DuplicateResponse — тело ответа 409 при обнаружении возможных дублей.
+type DuplicateResponse struct {
+	Errors     string               `json:"errors"`
+	Candidates []DuplicateCandidate `json:"candidates"`
+}
+
+type MergeRequest struct {
+	DuplicateID int64 `json:"duplicate_id"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrVersionConflict — инцидент изменён после того, как клиент получил его версию.
+	ErrVersionConflict = errors.New("incident version conflict")
+	// ErrIncidentNotDeleted — операция допустима только для удалённого инцидента.
+	ErrIncidentNotDeleted = errors.New("incident is not deleted")
+	// ErrRetentionPeriod — срок хранения удалённого инцидента ещё не истёк.
+	ErrRetentionPeriod = errors.New("retention period has not elapsed")
+	// ErrTrackUnsupported — трек поддерживается только для круговых зон.
+	ErrTrackUnsupported = errors.New("tracking is supported only for radius-based incidents")
+	// ErrMergeConflict — дубль уже удалён или объединён, либо типы инцидентов различаются.
+	ErrMergeConflict = errors.New("incidents cannot be merged")
+	// ErrIdempotencyInFlight — запрос с тем же Idempotency-Key ещё выполняется.
+	ErrIdempotencyInFlight = errors.New("request with this idempotency key is in progress")
+	// ErrIdempotencyKeyReused — Idempotency-Key уже использован с другим телом запроса.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrIncidentTypeExists — тип с таким кодом уже есть в справочнике.
+	ErrIncidentTypeExists = errors.New("incident type already exists")
+	// ErrIncidentTypeInUse — тип нельзя удалить, пока на него ссылаются инциденты.
+	ErrIncidentTypeInUse = errors.New("incident type is in use")
+	// ErrIncidentTemplateExists — шаблон с таким именем уже есть.
+	ErrIncidentTemplateExists = errors.New("incident template already exists")
+	// ErrUnknownIncidentType — тип инцидента не найден в справочнике.
+	ErrUnknownIncidentType = fmt.Errorf("%w: unknown incident type", ErrValidation)
+	// ErrBoundaryExists — граница с таким кодом уже загружена.
+	ErrBoundaryExists = errors.New("boundary already exists")
+	// ErrBoundaryInUse — на границу ссылаются инциденты или вложенные границы.
+	ErrBoundaryInUse = errors.New("boundary is in use")
+	// ErrNotRecurring — операция допустима только для повторяющегося инцидента.
+	ErrNotRecurring = errors.New("incident is not recurring")
+	// ErrTransitionNotAllowed — из текущего состояния инцидента нет такого перехода.
+	ErrTransitionNotAllowed = errors.New("incident status transition is not allowed")
+	// ErrTransitionForbidden — переход не разрешён роли оператора.
+	ErrTransitionForbidden = errors.New("incident status transition is forbidden for this role")
+	// ErrReviewedIncidentEdit — согласованный инцидент может менять только администратор.
+	ErrReviewedIncidentEdit = errors.New("reviewed incident can be changed only by admin")
+)
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/rusinadaria/geo-notification-system/internal/geo"
+)
+
+const (
+	maxExclusions       = 50
+	maxExclusionNameLen = 100
+)
+
+// Exclusion — безопасная область внутри зоны инцидента (убежище, больница,
+// станция метро): кругом (latitude, longitude, radius_meters) либо
+// GeoJSON-геометрией Polygon/MultiPolygon.
+type Exclusion struct {
+	Name         string          `json:"name,omitempty"`
+	Latitude     float64         `json:"latitude,omitempty"`
+	Longitude    float64         `json:"longitude,omitempty"`
+	RadiusMeters int             `json:"radius_meters,omitempty"`
+	Geometry     json.RawMessage `json:"geometry,omitempty"`
+}
+
+// Exclusions — безопасные области инцидента, хранятся в JSONB.
+type Exclusions []Exclusion
+
+func (e Exclusions) Value() (driver.Value, error) {
+	if len(e) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(e)
+}
+
+func (e *Exclusions) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	case nil:
+		*e = nil
+		return nil
+	default:
+		return errors.New("unsupported type for incident exclusions")
+	}
+}
+
+func (e Exclusions) Validate() error {
+	if len(e) > maxExclusions {
+		return validationError("at most %d exclusions are allowed", maxExclusions)
+	}
+
+	for i, ex := range e {
+		if utf8.RuneCountInString(ex.Name) > maxExclusionNameLen {
+			return validationError("exclusions[%d]: name must be at most %d characters", i, maxExclusionNameLen)
+		}
+
+		if HasGeometry(ex.Geometry) {
+			if _, err := geo.ParseZone(ex.Geometry); err != nil {
+				return validationError("exclusions[%d]: %v", i, err)
+			}
+			continue
+		}
+
+		if ex.Latitude < -90 || ex.Latitude > 90 {
+			return validationError("exclusions[%d]: latitude must be between -90 and 90", i)
+		}
+		if ex.Longitude < -180 || ex.Longitude > 180 {
+			return validationError("exclusions[%d]: longitude must be between -180 and 180", i)
+		}
+		if ex.RadiusMeters <= 0 {
+			return validationError("exclusions[%d]: radius_meters must be > 0", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	SortCreatedDesc = "-created_at"
+	SortCreatedAsc  = "created_at"
+	SortUpdatedDesc = "-updated_at"
+	SortUpdatedAsc  = "updated_at"
+	SortDistance    = "distance"
+)
+
+// IncidentFilter — параметры выборки инцидентов для списка и экспорта.
+// Нулевой Limit означает «без ограничения», nil-поля — «не фильтровать».
+type IncidentFilter struct {
+	Limit  int
+	Offset int
+
+	BBox  *BBox
+	Point *GeoPoint
+	// WithinMeters ограничивает выборку зонами не дальше заданного расстояния от Point
+	WithinMeters *float64
+
+	Types    []string
+	Statuses []IncidentStatus
+	Active   *bool
+	Live     *bool
+	// IncludeDeleted — включать удалённые инциденты, по умолчанию они скрыты
+	IncludeDeleted bool
+
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+
+	Sort string
+	// After — курсор предыдущей страницы; продолжает выборку после него
+	After *Cursor
+	// WithTotal — посчитать общее число подходящих записей
+	WithTotal bool
+}
+
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
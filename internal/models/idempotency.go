@@ -0,0 +1,18 @@
+package models
+
+// Состояния ключа идемпотентности.
+const (
+	IdempotencyPending = "pending"
+	IdempotencyDone    = "done"
+)
+
+// IdempotentResponse — сохранённый ответ на запрос с Idempotency-Key.
+// Fingerprint — хеш тела первого запроса: повтор ключа с другим телом
+// считается ошибкой клиента.
+type IdempotentResponse struct {
+	State       string            `json:"state"`
+	Fingerprint string            `json:"fingerprint"`
+	Status      int               `json:"status,omitempty"`
+	Header      map[string]string `json:"header,omitempty"`
+	Body        []byte            `json:"body,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+const (
+	ImportModeAtomic  = "atomic"
+	ImportModePartial = "partial"
+)
+
+// ImportRow — инцидент, прочитанный из файла импорта. Line — номер строки файла,
+// с которой начинается запись; Err — ошибка разбора этой записи.
+type ImportRow struct {
+	Line    int
+	Request IncidentRequest
+	Err     error
+}
+
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	Mode       string        `json:"mode"`
+	Total      int           `json:"total"`
+	CreatedIDs []int64       `json:"created_ids"`
+	Errors     []ImportError `json:"errors"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxTemplateNameLen        = 100
+	maxTemplateDescriptionLen = 5000
+)
+
+// templatePlaceholder — подстановка в описании шаблона: {{street}}.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([a-z0-9_]+)\s*\}\}`)
+
+// IncidentTemplate — шаблон инцидента. Description может содержать
+// подстановки {{name}}, значения которых передаются при создании инцидента.
+type IncidentTemplate struct {
+	ID           int64     `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	Type         string    `json:"type" db:"type"`
+	Severity     Severity  `json:"severity,omitempty" db:"severity"`
+	Description  string    `json:"description" db:"description"`
+	RadiusMeters int       `json:"radius_meters,omitempty" db:"radius_meters"`
+	Tiers        Tiers     `json:"tiers,omitempty" db:"tiers"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TemplateRef — ссылка на шаблон в запросе на создание инцидента. Остальные
+// поля запроса переопределяют значения шаблона.
+type TemplateRef struct {
+	TemplateID   int64             `json:"template_id"`
+	Placeholders map[string]string `json:"placeholders"`
+}
+
+func (t IncidentTemplate) Validate() error {
+	if t.Name == "" {
+		return validationError("name is required")
+	}
+	if utf8.RuneCountInString(t.Name) > maxTemplateNameLen {
+		return validationError("name must be at most %d characters", maxTemplateNameLen)
+	}
+	if t.Type == "" {
+		return validationError("type is required")
+	}
+	if utf8.RuneCountInString(t.Type) > maxIncidentTypeLen {
+		return validationError("type must be at most %d characters", maxIncidentTypeLen)
+	}
+	if t.Severity != "" && !t.Severity.Valid() {
+		return validationError("severity must be one of info, warning, danger, critical")
+	}
+	if utf8.RuneCountInString(t.Description) > maxTemplateDescriptionLen {
+		return validationError("description must be at most %d characters", maxTemplateDescriptionLen)
+	}
+	if t.RadiusMeters < 0 {
+		return validationError("radius_meters must be > 0")
+	}
+	if err := t.Tiers.Validate(false); err != nil {
+		return err
+	}
+	if len(t.Tiers) > 0 && t.RadiusMeters != 0 && t.RadiusMeters != t.Tiers.Outer() {
+		return validationError("radius_meters must match the outermost tier or be omitted")
+	}
+
+	return nil
+}
+
+// Request собирает запрос на создание инцидента из шаблона.
+func (t IncidentTemplate) Request() IncidentRequest {
+	return IncidentRequest{
+		Type:         t.Type,
+		Severity:     t.Severity,
+		Description:  t.Description,
+		RadiusMeters: t.RadiusMeters,
+		Tiers:        t.Tiers,
+	}
+}
+
+// RenderDescription подставляет значения в описание. Подстановка без
+// значения — ошибка валидации.
+func RenderDescription(description string, values map[string]string) (string, error) {
+	var missing string
+	rendered := templatePlaceholder.ReplaceAllStringFunc(description, func(m string) string {
+		name := templatePlaceholder.FindStringSubmatch(m)[1]
+		v, ok := values[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+	if missing != "" {
+		return "", validationError("no value for placeholder {{%s}}", missing)
+	}
+	return rendered, nil
+}
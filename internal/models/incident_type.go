@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+var incidentTypeCode = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+const maxIconLen = 100
+
+// IncidentType — запись справочника типов инцидентов.
+type IncidentType struct {
+	Code                string         `json:"code" db:"code"`
+	Names               LocalizedNames `json:"names" db:"names"`
+	DefaultRadiusMeters int            `json:"default_radius_meters,omitempty" db:"default_radius_meters"`
+	DefaultSeverity     Severity       `json:"default_severity" db:"default_severity"`
+	Icon                string         `json:"icon,omitempty" db:"icon"`
+	CreatedAt           time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// LocalizedNames — названия по языкам: {"ru": "Пожар", "en": "Fire"}.
+type LocalizedNames map[string]string
+
+func (n LocalizedNames) Value() (driver.Value, error) {
+	if n == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(n)
+}
+
+func (n *LocalizedNames) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, n)
+	case string:
+		return json.Unmarshal([]byte(v), n)
+	case nil:
+		*n = LocalizedNames{}
+		return nil
+	default:
+		return errors.New("unsupported type for localized names")
+	}
+}
+
+// ValidateCode проверяет формат кода нового типа. Коды, перенесённые из
+// старых инцидентов, могут быть кириллицей: их формат не проверяется, чтобы
+// такие типы оставались редактируемыми.
+func (t IncidentType) ValidateCode() error {
+	if len(t.Code) > maxIncidentTypeLen || !incidentTypeCode.MatchString(t.Code) {
+		return validationError("code must be lowercase latin letters, digits and underscores, at most %d characters", maxIncidentTypeLen)
+	}
+	return nil
+}
+
+// Validate проверяет запись справочника перед сохранением.
+func (t IncidentType) Validate() error {
+	if t.Code == "" {
+		return validationError("code is required")
+	}
+
+	if len(t.Names) == 0 {
+		return validationError("names must contain at least one language")
+	}
+	for lang, name := range t.Names {
+		if lang == "" || name == "" {
+			return validationError("names must map a language code to a non-empty name")
+		}
+	}
+
+	if t.DefaultRadiusMeters < 0 {
+		return validationError("default_radius_meters must be > 0")
+	}
+	if t.DefaultSeverity != "" && !t.DefaultSeverity.Valid() {
+		return validationError("default_severity must be one of info, warning, danger, critical")
+	}
+	if utf8.RuneCountInString(t.Icon) > maxIconLen {
+		return validationError("icon must be at most %d characters", maxIconLen)
+	}
+
+	return nil
+}
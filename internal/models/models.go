@@ -1,28 +1,75 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
+const (
+	EventDangerDetected  = "danger_detected"
+	EventIncidentNearby  = "incident_nearby"
+	EventWarningTier     = "incident_warning_tier"
+	EventInfoTier        = "incident_info_tier"
+	EventIncidentStarted = "incident_started"
+	EventIncidentEnded   = "incident_ended"
+)
+
+// WebhookPayload — событие для внешнего вебхука. Для событий о самом инциденте
+// (incident_started, incident_ended) заполняется Incident, а lat/lon — его координаты.
 type WebhookPayload struct {
 	Event      string                   `json:"event"`
-	UserID     int                      `json:"user_id"`
+	UserID     int                      `json:"user_id,omitempty"`
 	Lat        float64                  `json:"lat"`
 	Lon        float64                  `json:"lon"`
-	Incidents  []NearbyIncidentResponse `json:"incidents"`
+	Incidents  []NearbyIncidentResponse `json:"incidents,omitempty"`
+	Incident   *IncidentEvent           `json:"incident,omitempty"`
+	Severity   Severity                 `json:"severity,omitempty"`
+	Priority   string                   `json:"priority,omitempty"`
 	CheckedAt  time.Time                `json:"checked_at"`
 	RetryCount int                      `json:"retry_count,omitempty"`
 }
 
+type IncidentEvent struct {
+	ID        int64      `json:"id" db:"id"`
+	Type      string     `json:"type" db:"type"`
+	Severity  Severity   `json:"severity" db:"severity"`
+	Latitude  float64    `json:"latitude" db:"latitude"`
+	Longitude float64    `json:"longitude" db:"longitude"`
+	StartsAt  *time.Time `json:"starts_at,omitempty" db:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at,omitempty" db:"ends_at"`
+}
+
+// LocationCheckResponse.Danger определяется политикой оповещений по важности
+// инцидентов, в кольцо danger которых попала точка, Severity — наибольшая
+// важность среди найденных, Tier — самое внутреннее из их колец.
 type LocationCheckResponse struct {
 	Danger    bool                     `json:"danger"`
+	Severity  Severity                 `json:"severity,omitempty"`
+	Tier      TierLevel                `json:"tier,omitempty"`
 	Incidents []NearbyIncidentResponse `json:"incidents"`
+	// SafePockets — инциденты, в зоне которых точка находится, но внутри
+	// их безопасной области; опасности по ним нет.
+	SafePockets []SafePocket `json:"safe_pockets,omitempty"`
+}
+
+type SafePocket struct {
+	IncidentID int64  `json:"incident_id"`
+	Type       string `json:"type"`
+	Exclusion  string `json:"exclusion,omitempty"`
 }
 
+// NearbyIncidentResponse.Tier — самое внутреннее кольцо зоны, в котором
+// находится точка; NextTier — соседнее более опасное кольцо и расстояние до его границы.
 type NearbyIncidentResponse struct {
-	ID             int64   `json:"id"`
-	Type           string  `json:"type"`
-	DistanceMeters float64 `json:"distance_meters"`
+	ID                       int64     `json:"id"`
+	Type                     string    `json:"type"`
+	Severity                 Severity  `json:"severity"`
+	DistanceMeters           float64   `json:"distance_meters"`
+	Tier                     TierLevel `json:"tier"`
+	NextTier                 TierLevel `json:"next_tier,omitempty"`
+	DistanceToNextTierMeters *float64  `json:"distance_to_next_tier_meters,omitempty"`
+	// Boundary — самая мелкая граница зоны, в которой находится пользователь
+	Boundary *BoundaryRef `json:"boundary,omitempty"`
 }
 
 type LocationCheckRequest struct {
@@ -34,35 +81,124 @@ type LocationCheckRequest struct {
 type Incident struct {
 	ID          int64     `json:"id" db:"id"`
 	Type        string    `json:"type"`
+	Severity    Severity  `json:"severity" db:"severity"`
 	Description string    `json:"description" db:"description"`
 	Active      bool      `json:"active" db:"active"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// IncidentRequest описывает зону инцидента либо кругом (latitude, longitude,
+// radius_meters), либо GeoJSON-геометрией Polygon/MultiPolygon в поле geometry,
+// либо линией LineString/MultiLineString с буфером buffer_meters по обе стороны.
+// starts_at/ends_at задают окно действия; пустая граница означает «без ограничения».
+// tiers делит зону на кольца danger/warning/info; без них вся зона — одно кольцо danger.
+// exclusions — безопасные области внутри зоны, где об инциденте не оповещают.
+// boundary_ids задаёт зону объединением административных границ вместо геометрии.
+// radius_schedule меняет радиус круговой зоны со временем от начала действия.
+// recurrence делает инцидент повторяющимся: он действует только во время повторений.
 type IncidentRequest struct {
-	Type         string  `json:"type"`
-	Description  string  `json:"description"`
-	Latitude     float64 `json:"latitude"`
-	Longitude    float64 `json:"longitude"`
-	RadiusMeters int     `json:"radius_meters"`
-	Active       bool    `json:"active"`
+	Type           string          `json:"type"`
+	Severity       Severity        `json:"severity,omitempty"`
+	Description    string          `json:"description"`
+	Latitude       float64         `json:"latitude"`
+	Longitude      float64         `json:"longitude"`
+	RadiusMeters   int             `json:"radius_meters"`
+	RadiusSchedule RadiusSchedule  `json:"radius_schedule,omitempty"`
+	Geometry       json.RawMessage `json:"geometry,omitempty"`
+	BufferMeters   int             `json:"buffer_meters,omitempty"`
+	BoundaryIDs    BoundaryIDs     `json:"boundary_ids,omitempty"`
+	Tiers          Tiers           `json:"tiers,omitempty"`
+	Exclusions     Exclusions      `json:"exclusions,omitempty"`
+	Active         bool            `json:"active"`
+	StartsAt       *time.Time      `json:"starts_at,omitempty"`
+	EndsAt         *time.Time      `json:"ends_at,omitempty"`
+	Recurrence     *Recurrence     `json:"recurrence,omitempty"`
 }
 
+// IncidentResponse — инцидент в сохранённом виде. Для зоны с radius_schedule
+// effective_radius_meters — радиус на момент запроса. live учитывает status:
+// оповещения идут только по опубликованным инцидентам. У повторяющегося
+// инцидента occurrence_starts_at и occurrence_ends_at — текущее или ближайшее
+// повторение, live — идёт ли оно сейчас.
 type IncidentResponse struct {
-	Type         string    `json:"type" db:"type"`
-	Description  string    `json:"description" db:"description"`
-	Latitude     float64   `json:"latitude" db:"latitude"`
-	Longitude    float64   `json:"longitude" db:"longitude"`
-	RadiusMeters int       `json:"radius_meters" db:"radius_meters"`
-	Active       bool      `json:"active" db:"is_active"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID                    int64           `json:"id" db:"id"`
+	Type                  string          `json:"type" db:"type"`
+	Severity              Severity        `json:"severity" db:"severity"`
+	Description           string          `json:"description" db:"description"`
+	Latitude              float64         `json:"latitude" db:"latitude"`
+	Longitude             float64         `json:"longitude" db:"longitude"`
+	RadiusMeters          int             `json:"radius_meters" db:"radius_meters"`
+	EffectiveRadiusMeters int             `json:"effective_radius_meters,omitempty" db:"effective_radius_meters"`
+	RadiusSchedule        RadiusSchedule  `json:"radius_schedule,omitempty" db:"radius_schedule"`
+	Geometry              json.RawMessage `json:"geometry,omitempty" db:"geometry"`
+	BufferMeters          int             `json:"buffer_meters,omitempty" db:"buffer_meters"`
+	BoundaryIDs           BoundaryIDs     `json:"boundary_ids,omitempty" db:"boundary_ids"`
+	Tiers                 Tiers           `json:"tiers,omitempty" db:"tiers"`
+	Exclusions            Exclusions      `json:"exclusions,omitempty" db:"exclusions"`
+	Active                bool            `json:"active" db:"is_active"`
+	StartsAt              *time.Time      `json:"starts_at,omitempty" db:"starts_at"`
+	EndsAt                *time.Time      `json:"ends_at,omitempty" db:"ends_at"`
+	Recurrence            *Recurrence     `json:"recurrence,omitempty" db:"recurrence"`
+	OccurrenceStartsAt    *time.Time      `json:"occurrence_starts_at,omitempty" db:"occurrence_starts_at"`
+	OccurrenceEndsAt      *time.Time      `json:"occurrence_ends_at,omitempty" db:"occurrence_ends_at"`
+	Status                IncidentStatus  `json:"status,omitempty" db:"status"`
+	Live                  bool            `json:"live" db:"live"`
+	CreatedAt             time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time       `json:"updated_at" db:"updated_at"`
+	DeletedAt             *time.Time      `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy             *string         `json:"deleted_by,omitempty" db:"deleted_by"`
+	MergedInto            *int64          `json:"merged_into,omitempty" db:"merged_into"`
+	// Version увеличивается при каждом изменении; отдаётся клиенту в ETag.
+	// Трек движения в версионируемое состояние не входит
+	Version int64 `json:"-" db:"version"`
+}
+
+// ChangeMeta — кто и почему меняет инцидент; сохраняется в истории изменений.
+type ChangeMeta struct {
+	Operator string
+	// Role — роль оператора; от неё зависит, можно ли менять согласованный инцидент
+	Role   string
+	Reason string
+	// IfVersion — ожидаемая версия инцидента (If-Match); 0 — без проверки
+	IfVersion int64
+}
+
+const (
+	RevisionCreate     = "create"
+	RevisionUpdate     = "update"
+	RevisionDelete     = "delete"
+	RevisionRestore    = "restore"
+	RevisionMerge      = "merge"
+	RevisionTransition = "transition"
+	RevisionCancel     = "cancel"
+	RevisionExpire     = "expire"
+)
+
+// IncidentRevision — запись истории инцидента с полным состоянием до и после изменения.
+type IncidentRevision struct {
+	IncidentID int64           `json:"incident_id" db:"incident_id"`
+	Revision   int             `json:"revision" db:"revision"`
+	Action     string          `json:"action" db:"action"`
+	Operator   string          `json:"operator" db:"operator"`
+	Reason     string          `json:"reason,omitempty" db:"reason"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"`
+	After      json.RawMessage `json:"after,omitempty" db:"after"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
 }
 
 type IncidentStatsResponse struct {
-	UserCount    int64 `json:"user_count"`
-	WindowMinute int   `json:"window_minutes"`
+	UserCount    int64              `json:"user_count"`
+	WindowMinute int                `json:"window_minutes"`
+	ByType       []IncidentTypeStat `json:"by_type"`
+}
+
+// IncidentTypeStat — число инцидентов одного типа справочника.
+type IncidentTypeStat struct {
+	Code  string         `json:"code" db:"code"`
+	Names LocalizedNames `json:"names" db:"names"`
+	Live  int64          `json:"live" db:"live"`
+	Total int64          `json:"total" db:"total"`
 }
 
 type HealthStatus string
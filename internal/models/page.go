@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// IncidentPage — страница списка инцидентов. NextCursor пуст, если страниц
+// больше нет или сортировка не поддерживает курсоры (sort=distance).
+type IncidentPage struct {
+	Items      []IncidentResponse `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	HasMore    bool               `json:"has_more"`
+	Total      *int64             `json:"total,omitempty"`
+}
+
+// Cursor указывает на последнюю отданную запись: значение ключа сортировки
+// и id для разрешения одинаковых меток времени. Клиенту отдаётся как
+// непрозрачная строка.
+type Cursor struct {
+	Sort string    `json:"s"`
+	Time time.Time `json:"t"`
+	ID   int64     `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// SupportsCursor сообщает, можно ли листать выборку с данной сортировкой курсором.
+func SupportsCursor(sort string) bool {
+	switch sort {
+	case SortCreatedDesc, SortCreatedAsc, SortUpdatedDesc, SortUpdatedAsc:
+		return true
+	default:
+		return false
+	}
+}
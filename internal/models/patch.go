@@ -0,0 +1,275 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+)
+
+// Field — поле merge-patch документа (RFC 7396). Set означает, что ключ
+// присутствовал в документе, Null — что ему явно передан null.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// IncidentPatch — частичное изменение инцидента. Отсутствующие поля не
+// меняются; null сбрасывает необязательные поля (description, radius_schedule,
+// geometry, buffer_meters, boundary_ids, tiers, exclusions, starts_at, ends_at,
+// recurrence). Объект recurrence сливается с текущим правилом по полям.
+// Массивы, как и требует RFC 7396, заменяются целиком; geometry — тоже:
+// GeoJSON-геометрия без type или coordinates не имеет смысла.
+type IncidentPatch struct {
+	Type           Field[string]          `json:"type"`
+	Severity       Field[Severity]        `json:"severity"`
+	Description    Field[string]          `json:"description"`
+	Latitude       Field[float64]         `json:"latitude"`
+	Longitude      Field[float64]         `json:"longitude"`
+	RadiusMeters   Field[int]             `json:"radius_meters"`
+	RadiusSchedule Field[RadiusSchedule]  `json:"radius_schedule"`
+	Geometry       Field[json.RawMessage] `json:"geometry"`
+	BufferMeters   Field[int]             `json:"buffer_meters"`
+	BoundaryIDs    Field[BoundaryIDs]     `json:"boundary_ids"`
+	Tiers          Field[Tiers]           `json:"tiers"`
+	Exclusions     Field[Exclusions]      `json:"exclusions"`
+	Active         Field[bool]            `json:"active"`
+	StartsAt       Field[time.Time]       `json:"starts_at"`
+	EndsAt         Field[time.Time]       `json:"ends_at"`
+	Recurrence     Field[RecurrencePatch] `json:"recurrence"`
+}
+
+// RecurrencePatch — merge-patch правила повторения: переданные поля заменяют
+// поля текущего правила, остальные сохраняются; null у timezone возвращает UTC.
+type RecurrencePatch struct {
+	RRule           Field[string] `json:"rrule"`
+	DurationSeconds Field[int]    `json:"duration_seconds"`
+	Timezone        Field[string] `json:"timezone"`
+}
+
+// UnmarshalJSON отвергает неизвестные поля: иначе опечатка в имени поля
+// молча оставила бы правило без изменений.
+func (p *RecurrencePatch) UnmarshalJSON(data []byte) error {
+	type plain RecurrencePatch
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*plain)(p))
+}
+
+func (p RecurrencePatch) apply(cur *Recurrence) Recurrence {
+	var r Recurrence
+	if cur != nil {
+		r = *cur
+	}
+	if p.RRule.Set {
+		r.RRule = p.RRule.Value
+	}
+	if p.DurationSeconds.Set {
+		r.DurationSeconds = p.DurationSeconds.Value
+	}
+	if p.Timezone.Set {
+		r.Timezone = p.Timezone.Value
+	}
+	return r
+}
+
+// Validate проверяет каждое переданное поле по отдельности. Согласованность
+// полей между собой проверяется после применения патча через IncidentRequest.Validate.
+func (p IncidentPatch) Validate() error {
+	switch {
+	case p.Type.Null:
+		return validationError("type cannot be null")
+	case p.Severity.Null:
+		return validationError("severity cannot be null")
+	case p.Latitude.Null:
+		return validationError("latitude cannot be null")
+	case p.Longitude.Null:
+		return validationError("longitude cannot be null")
+	case p.RadiusMeters.Null:
+		return validationError("radius_meters cannot be null")
+	case p.Active.Null:
+		return validationError("active cannot be null")
+	}
+
+	if p.Type.Set {
+		if p.Type.Value == "" {
+			return validationError("type is required")
+		}
+		if utf8.RuneCountInString(p.Type.Value) > maxIncidentTypeLen {
+			return validationError("type must be at most %d characters", maxIncidentTypeLen)
+		}
+	}
+	if p.Severity.Set && !p.Severity.Value.Valid() {
+		return validationError("severity must be one of info, warning, danger, critical")
+	}
+	if p.Latitude.Set && (p.Latitude.Value < -90 || p.Latitude.Value > 90) {
+		return validationError("latitude must be between -90 and 90")
+	}
+	if p.Longitude.Set && (p.Longitude.Value < -180 || p.Longitude.Value > 180) {
+		return validationError("longitude must be between -180 and 180")
+	}
+	if p.RadiusMeters.Set && p.RadiusMeters.Value <= 0 {
+		return validationError("radius_meters must be > 0")
+	}
+	if p.RadiusSchedule.Set {
+		if err := p.RadiusSchedule.Value.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.Geometry.Set && !p.Geometry.Null {
+		if err := validateGeometry(p.Geometry.Value); err != nil {
+			return validationError("%v", err)
+		}
+	}
+	if p.BufferMeters.Set && !p.BufferMeters.Null && p.BufferMeters.Value <= 0 {
+		return validationError("buffer_meters must be > 0")
+	}
+	if p.BoundaryIDs.Set {
+		if err := p.BoundaryIDs.Value.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.Tiers.Set {
+		// нулевой радиус допустим только для полигона — это проверится после Apply
+		if err := p.Tiers.Value.Validate(true); err != nil {
+			return err
+		}
+	}
+	if p.Exclusions.Set {
+		if err := p.Exclusions.Value.Validate(); err != nil {
+			return err
+		}
+	}
+	// само правило проверяется после слияния с текущим
+	if p.Recurrence.Set && !p.Recurrence.Null {
+		switch {
+		case p.Recurrence.Value.RRule.Null:
+			return validationError("recurrence.rrule cannot be null")
+		case p.Recurrence.Value.DurationSeconds.Null:
+			return validationError("recurrence.duration_seconds cannot be null")
+		}
+	}
+
+	return nil
+}
+
+// Apply накладывает патч на текущее состояние инцидента и возвращает
+// полный запрос на изменение. Радиус можно менять только у круговой зоны.
+func (p IncidentPatch) Apply(cur IncidentResponse) (IncidentRequest, error) {
+	req := IncidentRequest{
+		Type:           cur.Type,
+		Severity:       cur.Severity,
+		Description:    cur.Description,
+		Latitude:       cur.Latitude,
+		Longitude:      cur.Longitude,
+		RadiusMeters:   cur.RadiusMeters,
+		RadiusSchedule: cur.RadiusSchedule,
+		Geometry:       cur.Geometry,
+		BufferMeters:   cur.BufferMeters,
+		BoundaryIDs:    cur.BoundaryIDs,
+		Tiers:          cur.Tiers,
+		Exclusions:     cur.Exclusions,
+		Active:         cur.Active,
+		StartsAt:       cur.StartsAt,
+		EndsAt:         cur.EndsAt,
+		Recurrence:     cur.Recurrence,
+	}
+	// для зоны из границ geometry в ответе — их объединение, а не часть запроса
+	if len(cur.BoundaryIDs) > 0 {
+		req.Geometry = nil
+	}
+
+	if p.Type.Set {
+		req.Type = p.Type.Value
+	}
+	if p.Severity.Set {
+		req.Severity = p.Severity.Value
+	}
+	if p.Description.Set {
+		req.Description = p.Description.Value
+	}
+	if p.Latitude.Set {
+		req.Latitude = p.Latitude.Value
+	}
+	if p.Longitude.Set {
+		req.Longitude = p.Longitude.Value
+	}
+	if p.RadiusMeters.Set {
+		req.RadiusMeters = p.RadiusMeters.Value
+	}
+	if p.RadiusSchedule.Set {
+		req.RadiusSchedule = p.RadiusSchedule.Value
+		// радиус круга с расписанием берётся из расписания
+		if len(req.RadiusSchedule) > 0 && !p.RadiusMeters.Set {
+			req.RadiusMeters = 0
+		}
+	}
+	if p.Geometry.Set {
+		req.Geometry = nil
+		if !p.Geometry.Null {
+			req.Geometry = p.Geometry.Value
+			req.BoundaryIDs = nil
+		}
+	}
+	if p.BoundaryIDs.Set {
+		req.BoundaryIDs = p.BoundaryIDs.Value
+		if len(req.BoundaryIDs) > 0 {
+			req.Geometry = nil
+			req.RadiusMeters = 0
+		}
+	}
+	if p.BufferMeters.Set {
+		req.BufferMeters = p.BufferMeters.Value
+	}
+	if p.Tiers.Set {
+		req.Tiers = p.Tiers.Value
+		// радиус круга и буфер линии с кольцами берутся из внешнего кольца
+		if len(req.Tiers) > 0 && !p.RadiusMeters.Set {
+			req.RadiusMeters = 0
+		}
+		if len(req.Tiers) > 0 && !p.BufferMeters.Set {
+			req.BufferMeters = 0
+		}
+	}
+	if p.Exclusions.Set {
+		req.Exclusions = p.Exclusions.Value
+	}
+	if p.Active.Set {
+		req.Active = p.Active.Value
+	}
+	if p.StartsAt.Set {
+		req.StartsAt = nil
+		if !p.StartsAt.Null {
+			req.StartsAt = &p.StartsAt.Value
+		}
+	}
+	if p.EndsAt.Set {
+		req.EndsAt = nil
+		if !p.EndsAt.Null {
+			req.EndsAt = &p.EndsAt.Value
+		}
+	}
+	if p.Recurrence.Set {
+		req.Recurrence = nil
+		if !p.Recurrence.Null {
+			rec := p.Recurrence.Value.apply(cur.Recurrence)
+			req.Recurrence = &rec
+		}
+	}
+
+	if p.RadiusMeters.Set && (HasGeometry(req.Geometry) || len(req.BoundaryIDs) > 0) {
+		return IncidentRequest{}, validationError("radius_meters is only allowed for radius-based incidents")
+	}
+
+	return req, nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncidentPatch_Recurrence(t *testing.T) {
+	startsAt := time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)
+	cur := IncidentResponse{
+		Type:         "fire",
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 300,
+		StartsAt:     &startsAt,
+		Recurrence:   &Recurrence{RRule: "FREQ=WEEKLY;BYDAY=SA", DurationSeconds: 3600, Timezone: "Europe/Moscow"},
+	}
+
+	testTable := []struct {
+		name        string
+		patch       string
+		expected    *Recurrence
+		expectedErr bool
+	}{
+		{
+			name:     "Duration only",
+			patch:    `{"recurrence":{"duration_seconds":7200}}`,
+			expected: &Recurrence{RRule: "FREQ=WEEKLY;BYDAY=SA", DurationSeconds: 7200, Timezone: "Europe/Moscow"},
+		},
+		{
+			name:     "Null timezone",
+			patch:    `{"recurrence":{"timezone":null}}`,
+			expected: &Recurrence{RRule: "FREQ=WEEKLY;BYDAY=SA", DurationSeconds: 3600},
+		},
+		{
+			name:     "Null recurrence",
+			patch:    `{"recurrence":null}`,
+			expected: nil,
+		},
+		{
+			name:        "Null rrule",
+			patch:       `{"recurrence":{"rrule":null}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "Unknown member",
+			patch:       `{"recurrence":{"duration":"2h"}}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			var patch IncidentPatch
+			err := json.Unmarshal([]byte(testCase.patch), &patch)
+			if err == nil {
+				err = patch.Validate()
+			}
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req, err := patch.Apply(cur)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, req.Recurrence)
+			assert.NoError(t, req.Validate())
+		})
+	}
+
+	// текущее правило инцидента не меняется
+	assert.Equal(t, 3600, cur.Recurrence.DurationSeconds)
+}
+
+func TestIncidentPatch_RecurrenceOnNonRecurring(t *testing.T) {
+	startsAt := time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)
+	cur := IncidentResponse{Type: "fire", Latitude: 55.75, Longitude: 37.61, RadiusMeters: 300, StartsAt: &startsAt}
+
+	var patch IncidentPatch
+	require.NoError(t, json.Unmarshal([]byte(`{"recurrence":{"duration_seconds":7200}}`), &patch))
+
+	req, err := patch.Apply(cur)
+	require.NoError(t, err)
+	assert.ErrorIs(t, req.Validate(), ErrValidation)
+}
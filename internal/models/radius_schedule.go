@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+const maxRadiusSteps = 50
+
+// RadiusStep — шаг расписания радиуса: через OffsetSeconds после начала
+// действия инцидента радиус равен RadiusMeters.
+type RadiusStep struct {
+	OffsetSeconds int `json:"offset_seconds"`
+	RadiusMeters  int `json:"radius_meters"`
+}
+
+// RadiusSchedule — расписание радиуса круговой зоны, хранится в JSONB. Между
+// шагами радиус меняется линейно, после последнего шага не меняется.
+type RadiusSchedule []RadiusStep
+
+func (s RadiusSchedule) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *RadiusSchedule) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	case nil:
+		*s = nil
+		return nil
+	default:
+		return errors.New("unsupported type for radius schedule")
+	}
+}
+
+// Max возвращает наибольший радиус расписания.
+func (s RadiusSchedule) Max() int {
+	radius := 0
+	for _, step := range s {
+		radius = max(radius, step.RadiusMeters)
+	}
+	return radius
+}
+
+// Validate проверяет расписание: не меньше двух шагов, первый — в момент
+// начала действия, смещения строго растут, радиусы положительны.
+func (s RadiusSchedule) Validate() error {
+	if len(s) == 0 {
+		return nil
+	}
+	if len(s) < 2 {
+		return validationError("radius_schedule must have at least 2 steps")
+	}
+	if len(s) > maxRadiusSteps {
+		return validationError("radius_schedule must have at most %d steps", maxRadiusSteps)
+	}
+
+	for i, step := range s {
+		if step.RadiusMeters <= 0 {
+			return validationError("radius_schedule[%d]: radius_meters must be > 0", i)
+		}
+		if i == 0 && step.OffsetSeconds != 0 {
+			return validationError("radius_schedule must start at offset_seconds 0")
+		}
+		if i > 0 && step.OffsetSeconds <= s[i-1].OffsetSeconds {
+			return validationError("radius_schedule[%d]: offset_seconds must be greater than in the previous step", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/rusinadaria/geo-notification-system/internal/recurrence"
+)
+
+const maxOccurrenceDuration = 7 * 24 * 60 * 60
+
+// Recurrence — повторение инцидента по правилу RRULE (RFC 5545). Первое
+// повторение начинается в starts_at инцидента, каждое длится DurationSeconds.
+// Timezone — часовой пояс IANA, в котором правило разворачивается (по
+// умолчанию UTC): «каждую субботу в 10:00» остаётся в 10:00 по местному времени.
+type Recurrence struct {
+	RRule           string `json:"rrule"`
+	DurationSeconds int    `json:"duration_seconds"`
+	Timezone        string `json:"timezone,omitempty"`
+}
+
+// Occurrence — одно повторение инцидента.
+type Occurrence struct {
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Cancelled bool      `json:"cancelled,omitempty"`
+}
+
+// OccurrenceCancelRequest — отмена одного повторения по времени его начала.
+type OccurrenceCancelRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+}
+
+func (r Recurrence) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *Recurrence) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("unsupported type for incident recurrence")
+	}
+}
+
+func (r Recurrence) Validate() error {
+	if _, err := recurrence.Parse(r.RRule); err != nil {
+		return validationError("recurrence: %v", err)
+	}
+	if r.DurationSeconds <= 0 || r.DurationSeconds > maxOccurrenceDuration {
+		return validationError("recurrence: duration_seconds must be between 1 and %d", maxOccurrenceDuration)
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return validationError("recurrence: unknown timezone %q", r.Timezone)
+	}
+	return nil
+}
+
+func (r Recurrence) duration() time.Duration {
+	return time.Duration(r.DurationSeconds) * time.Second
+}
+
+// Each вызывает fn для повторений, ещё не закончившихся к from, по порядку,
+// пока fn возвращает true. dtstart — начало первого повторения, until —
+// конец серии (ends_at инцидента), nil — без ограничения.
+func (r Recurrence) Each(dtstart time.Time, until *time.Time, from time.Time, fn func(o Occurrence) bool) error {
+	rule, err := recurrence.Parse(r.RRule)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return err
+	}
+
+	rule.Each(dtstart.In(loc), from.Add(-r.duration()+time.Nanosecond), func(start time.Time) bool {
+		if until != nil && !start.Before(*until) {
+			return false
+		}
+		return fn(Occurrence{StartsAt: start, EndsAt: start.Add(r.duration())})
+	})
+
+	return nil
+}
+
+// hasOccurrences сообщает, есть ли в серии хоть одно повторение.
+func (r Recurrence) hasOccurrences(dtstart time.Time, until *time.Time) bool {
+	var found bool
+	r.Each(dtstart, until, dtstart, func(Occurrence) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// Next возвращает неотменённое повторение, идущее в момент at, или
+// ближайшее следующее. Конец повторения обрезается по концу серии until.
+func (r Recurrence) Next(dtstart time.Time, until *time.Time, at time.Time, cancelled []time.Time) (Occurrence, bool) {
+	var (
+		next  Occurrence
+		found bool
+	)
+
+	err := r.Each(dtstart, until, at, func(o Occurrence) bool {
+		if isCancelled(o.StartsAt, cancelled) {
+			return true
+		}
+		if until != nil && o.EndsAt.After(*until) {
+			o.EndsAt = *until
+		}
+		if !o.EndsAt.After(at) {
+			return true
+		}
+		next, found = o, true
+		return false
+	})
+
+	return next, err == nil && found
+}
+
+// IsOccurrence сообщает, начинается ли в start одно из повторений серии.
+func (r Recurrence) IsOccurrence(dtstart time.Time, until *time.Time, start time.Time) bool {
+	var found bool
+	r.Each(dtstart, until, start, func(o Occurrence) bool {
+		if o.StartsAt.Before(start) {
+			return true
+		}
+		found = o.StartsAt.Equal(start)
+		return false
+	})
+	return found
+}
+
+func isCancelled(start time.Time, cancelled []time.Time) bool {
+	for _, c := range cancelled {
+		if c.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package models
+
+// SortRelevance — сортировка результатов поиска по релевантности.
+const SortRelevance = "relevance"
+
+// IncidentSearchHit — найденный инцидент с рангом и фрагментом описания,
+// в котором совпавшие слова обёрнуты в <mark>, а остальной текст экранирован
+// как HTML.
+type IncidentSearchHit struct {
+	IncidentResponse
+	Rank    float64 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"`
+}
+
+type IncidentSearchResult struct {
+	Items   []IncidentSearchHit `json:"items"`
+	HasMore bool                `json:"has_more"`
+}
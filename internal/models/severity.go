@@ -0,0 +1,44 @@
+package models
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityDanger   Severity = "danger"
+	SeverityCritical Severity = "critical"
+)
+
+// DefaultSeverity присваивается инцидентам без явно указанной важности,
+// чтобы они вели себя как до появления уровней важности.
+const DefaultSeverity = SeverityDanger
+
+var severityRank = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityDanger:   3,
+	SeverityCritical: 4,
+}
+
+func (s Severity) Valid() bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+// Higher сообщает, важнее ли s, чем other.
+func (s Severity) Higher(other Severity) bool {
+	return severityRank[s] > severityRank[other]
+}
+
+func (s Severity) OrDefault() Severity {
+	if s == "" {
+		return DefaultSeverity
+	}
+	return s
+}
+
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// TierLevel — кольцо зоны инцидента. Чем ближе кольцо к инциденту, тем оно опаснее.
+type TierLevel string
+
+const (
+	TierDanger  TierLevel = "danger"
+	TierWarning TierLevel = "warning"
+	TierInfo    TierLevel = "info"
+)
+
+// tierOrder — порядок колец от внутреннего к внешнему.
+var tierOrder = map[TierLevel]int{
+	TierDanger:  1,
+	TierWarning: 2,
+	TierInfo:    3,
+}
+
+func (l TierLevel) Valid() bool {
+	_, ok := tierOrder[l]
+	return ok
+}
+
+// Inner сообщает, лежит ли кольцо l внутри other.
+func (l TierLevel) Inner(other TierLevel) bool {
+	if other == "" {
+		return l != ""
+	}
+	return tierOrder[l] != 0 && tierOrder[l] < tierOrder[other]
+}
+
+// Tier — кольцо зоны. Для круговой зоны RadiusMeters отсчитывается от центра
+// инцидента, для полигона — от границы полигона наружу (0 — сам полигон).
+type Tier struct {
+	Level        TierLevel `json:"level"`
+	RadiusMeters int       `json:"radius_meters"`
+}
+
+// Tiers — кольца зоны инцидента, хранятся в JSONB.
+type Tiers []Tier
+
+func (t Tiers) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(t.Sorted())
+}
+
+func (t *Tiers) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	case nil:
+		*t = nil
+		return nil
+	default:
+		return errors.New("unsupported type for incident tiers")
+	}
+}
+
+// Sorted возвращает кольца от внутреннего к внешнему.
+func (t Tiers) Sorted() Tiers {
+	sorted := append(Tiers(nil), t...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return tierOrder[sorted[i].Level] < tierOrder[sorted[j].Level]
+	})
+	return sorted
+}
+
+// Outer возвращает радиус внешнего кольца.
+func (t Tiers) Outer() int {
+	outer := 0
+	for _, tier := range t {
+		outer = max(outer, tier.RadiusMeters)
+	}
+	return outer
+}
+
+// Validate проверяет кольца: каждый уровень не больше одного раза, радиусы
+// растут от danger к info. polygon разрешает нулевой радиус внутреннего кольца.
+func (t Tiers) Validate(polygon bool) error {
+	seen := map[TierLevel]bool{}
+	for _, tier := range t {
+		if !tier.Level.Valid() {
+			return validationError("tier level must be one of danger, warning, info")
+		}
+		if seen[tier.Level] {
+			return validationError("tier %s is specified more than once", tier.Level)
+		}
+		seen[tier.Level] = true
+
+		if tier.RadiusMeters < 0 || (!polygon && tier.RadiusMeters == 0) {
+			return validationError("radius_meters of tier %s must be > 0", tier.Level)
+		}
+	}
+
+	sorted := t.Sorted()
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].RadiusMeters <= sorted[i-1].RadiusMeters {
+			return validationError("tier %s must be wider than tier %s", sorted[i].Level, sorted[i-1].Level)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const maxTrackPoints = 1000
+
+// TrackPoint — положение движущегося инцидента в момент RecordedAt.
+// Точки с RecordedAt в будущем задают прогноз маршрута.
+type TrackPoint struct {
+	Latitude   float64   `json:"latitude" db:"latitude"`
+	Longitude  float64   `json:"longitude" db:"longitude"`
+	RecordedAt time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+type TrackRequest struct {
+	Points []TrackPoint `json:"points"`
+}
+
+func (r TrackRequest) Validate() error {
+	if len(r.Points) == 0 {
+		return validationError("points must not be empty")
+	}
+	if len(r.Points) > maxTrackPoints {
+		return validationError("at most %d points per request", maxTrackPoints)
+	}
+
+	seen := make(map[time.Time]bool, len(r.Points))
+	for i, p := range r.Points {
+		if p.Latitude < -90 || p.Latitude > 90 {
+			return validationError("point %d: latitude must be between -90 and 90", i)
+		}
+		if p.Longitude < -180 || p.Longitude > 180 {
+			return validationError("point %d: longitude must be between -180 and 180", i)
+		}
+		if p.RecordedAt.IsZero() {
+			return validationError("point %d: recorded_at is required", i)
+		}
+		if seen[p.RecordedAt.UTC()] {
+			return validationError("point %d: duplicate recorded_at", i)
+		}
+		seen[p.RecordedAt.UTC()] = true
+	}
+
+	return nil
+}
+
+// TrackFeature — трек инцидента как GeoJSON Feature с геометрией LineString.
+// Пока в треке меньше двух точек, geometry равна null.
+type TrackFeature struct {
+	Type       string          `json:"type"`
+	Geometry   *TrackLine      `json:"geometry"`
+	Properties TrackProperties `json:"properties"`
+}
+
+type TrackLine struct {
+	Type        string       `json:"type"`
+	Coordinates [][2]float64 `json:"coordinates"`
+}
+
+// TrackProperties.Timestamps соответствуют координатам линии по порядку.
+type TrackProperties struct {
+	IncidentID int64       `json:"incident_id"`
+	Timestamps []time.Time `json:"timestamps"`
+}
+
+func NewTrackFeature(incidentID int64, points []TrackPoint) TrackFeature {
+	f := TrackFeature{
+		Type:       "Feature",
+		Properties: TrackProperties{IncidentID: incidentID, Timestamps: []time.Time{}},
+	}
+
+	coords := make([][2]float64, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, [2]float64{p.Longitude, p.Latitude})
+		f.Properties.Timestamps = append(f.Properties.Timestamps, p.RecordedAt)
+	}
+
+	if len(coords) >= 2 {
+		f.Geometry = &TrackLine{Type: "LineString", Coordinates: coords}
+	}
+
+	return f
+}
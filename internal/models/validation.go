@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/rusinadaria/geo-notification-system/internal/geo"
+)
+
+var ErrValidation = errors.New("validation error")
+
+const maxIncidentTypeLen = 50
+
+// Validate проверяет запрос на создание/изменение инцидента.
+// Возвращаемая ошибка оборачивает ErrValidation и пригодна для ответа клиенту.
+func (r IncidentRequest) Validate() error {
+	if r.Type == "" {
+		return validationError("type is required")
+	}
+	if utf8.RuneCountInString(r.Type) > maxIncidentTypeLen {
+		return validationError("type must be at most %d characters", maxIncidentTypeLen)
+	}
+
+	if r.Severity != "" && !r.Severity.Valid() {
+		return validationError("severity must be one of info, warning, danger, critical")
+	}
+
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return validationError("ends_at must be after starts_at")
+	}
+
+	if r.Recurrence != nil {
+		if r.StartsAt == nil {
+			return validationError("starts_at is required for recurring incidents")
+		}
+		if err := r.Recurrence.Validate(); err != nil {
+			return err
+		}
+		if !r.Recurrence.hasOccurrences(*r.StartsAt, r.EndsAt) {
+			return validationError("recurrence: rule has no occurrences")
+		}
+	}
+
+	polygon := (HasGeometry(r.Geometry) && !IsLine(r.Geometry)) || len(r.BoundaryIDs) > 0
+	if err := r.Tiers.Validate(polygon); err != nil {
+		return err
+	}
+	if err := r.Exclusions.Validate(); err != nil {
+		return err
+	}
+
+	if len(r.RadiusSchedule) > 0 {
+		if err := r.validateSchedule(); err != nil {
+			return err
+		}
+	}
+
+	if len(r.BoundaryIDs) > 0 {
+		return r.validateBoundaries()
+	}
+
+	if HasGeometry(r.Geometry) {
+		if err := validateGeometry(r.Geometry); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		if IsLine(r.Geometry) {
+			return validateZoneSize("buffer_meters", r.BufferMeters, r.Tiers)
+		}
+	}
+	if r.BufferMeters != 0 {
+		return validationError("buffer_meters is only allowed for line geometries")
+	}
+	if HasGeometry(r.Geometry) {
+		return nil
+	}
+
+	if r.Latitude < -90 || r.Latitude > 90 {
+		return validationError("latitude must be between -90 and 90")
+	}
+	if r.Longitude < -180 || r.Longitude > 180 {
+		return validationError("longitude must be between -180 and 180")
+	}
+
+	if len(r.RadiusSchedule) > 0 {
+		if r.RadiusMeters != 0 && r.RadiusMeters != r.RadiusSchedule.Max() {
+			return validationError("radius_meters must match the largest scheduled radius or be omitted")
+		}
+		return nil
+	}
+
+	// без радиуса круг получает радиус по умолчанию из справочника типов
+	if r.RadiusMeters == 0 && len(r.Tiers) == 0 {
+		return nil
+	}
+
+	return validateZoneSize("radius_meters", r.RadiusMeters, r.Tiers)
+}
+
+// NeedsRadius сообщает, что круговой зоне не задан радиус ни явно, ни
+// кольцами, ни расписанием.
+func (r IncidentRequest) NeedsRadius() bool {
+	return !HasGeometry(r.Geometry) && len(r.BoundaryIDs) == 0 &&
+		r.RadiusMeters == 0 && len(r.Tiers) == 0 && len(r.RadiusSchedule) == 0
+}
+
+// validateSchedule проверяет расписание радиуса: оно задаётся только для
+// круговой зоны без колец.
+func (r IncidentRequest) validateSchedule() error {
+	if HasGeometry(r.Geometry) || len(r.BoundaryIDs) > 0 {
+		return validationError("radius_schedule is only allowed for radius-based incidents")
+	}
+	if len(r.Tiers) > 0 {
+		return validationError("radius_schedule cannot be combined with tiers")
+	}
+	return r.RadiusSchedule.Validate()
+}
+
+// validateBoundaries проверяет зону, заданную административными границами:
+// она не сочетается с геометрией, радиусом и буфером.
+func (r IncidentRequest) validateBoundaries() error {
+	if HasGeometry(r.Geometry) {
+		return validationError("boundary_ids cannot be combined with geometry")
+	}
+	if r.RadiusMeters != 0 {
+		return validationError("radius_meters is not allowed with boundary_ids")
+	}
+	if r.BufferMeters != 0 {
+		return validationError("buffer_meters is only allowed for line geometries")
+	}
+	return r.BoundaryIDs.Validate()
+}
+
+// validateZoneSize проверяет радиус круга или ширину буфера линии. Для зоны
+// с кольцами размер берётся из внешнего кольца и может быть опущен.
+func validateZoneSize(field string, size int, tiers Tiers) error {
+	if len(tiers) > 0 {
+		if size != 0 && size != tiers.Outer() {
+			return validationError("%s must match the outermost tier or be omitted", field)
+		}
+		return nil
+	}
+	if size <= 0 {
+		return validationError("%s must be > 0", field)
+	}
+	return nil
+}
+
+// validateGeometry проверяет геометрию зоны: линию или полигон.
+func validateGeometry(raw []byte) error {
+	if geo.IsLine(raw) {
+		_, err := geo.ParseLine(raw)
+		return err
+	}
+	_, err := geo.ParseZone(raw)
+	return err
+}
+
+// IsLine сообщает, задана ли зона линией (LineString/MultiLineString).
+func IsLine(raw []byte) bool {
+	return HasGeometry(raw) && geo.IsLine(raw)
+}
+
+// HasGeometry сообщает, передана ли геометрия; явный null считается её отсутствием.
+func HasGeometry(raw []byte) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
+
+func validationError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrValidation, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// IncidentStatus — состояние инцидента в процессе согласования. Оповещения
+// идут только по опубликованным инцидентам.
+type IncidentStatus string
+
+const (
+	StatusDraft         IncidentStatus = "draft"
+	StatusPendingReview IncidentStatus = "pending_review"
+	StatusPublished     IncidentStatus = "published"
+	StatusResolved      IncidentStatus = "resolved"
+	StatusArchived      IncidentStatus = "archived"
+)
+
+// Роли операторов, которые назначает middleware.APIKeyAuth.
+const (
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+const maxTransitionCommentLen = 2000
+
+type transition struct {
+	from, to IncidentStatus
+}
+
+// transitionRoles — допустимые переходы и роли, которым они разрешены.
+// Публикует и возвращает на доработку только администратор.
+var transitionRoles = map[transition][]string{
+	{StatusDraft, StatusPendingReview}:     {RoleOperator, RoleAdmin},
+	{StatusPendingReview, StatusDraft}:     {RoleAdmin},
+	{StatusPendingReview, StatusPublished}: {RoleAdmin},
+	{StatusPublished, StatusResolved}:      {RoleOperator, RoleAdmin},
+	{StatusResolved, StatusArchived}:       {RoleOperator, RoleAdmin},
+}
+
+func (s IncidentStatus) Valid() bool {
+	switch s {
+	case StatusDraft, StatusPendingReview, StatusPublished, StatusResolved, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// CanTransition проверяет переход from → to для роли role. Несуществующий
+// переход — ErrTransitionNotAllowed, чужая роль — ErrTransitionForbidden.
+func CanTransition(from, to IncidentStatus, role string) error {
+	roles, ok := transitionRoles[transition{from, to}]
+	if !ok {
+		return ErrTransitionNotAllowed
+	}
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return ErrTransitionForbidden
+}
+
+// CanEdit проверяет, может ли роль role менять инцидент в состоянии status.
+// После публикации изменения идут в оповещения без повторного согласования,
+// поэтому их вносит только администратор.
+func CanEdit(status IncidentStatus, role string) error {
+	switch status {
+	case StatusPublished, StatusResolved, StatusArchived:
+		if role != RoleAdmin {
+			return ErrReviewedIncidentEdit
+		}
+	}
+	return nil
+}
+
+// TransitionRequest — тело POST /incidents/{id}/transitions.
+type TransitionRequest struct {
+	To      IncidentStatus `json:"to"`
+	Comment string         `json:"comment"`
+}
+
+func (r TransitionRequest) Validate() error {
+	if !r.To.Valid() {
+		return validationError("to must be one of draft, pending_review, published, resolved, archived")
+	}
+	if utf8.RuneCountInString(r.Comment) > maxTransitionCommentLen {
+		return validationError("comment must be at most %d characters", maxTransitionCommentLen)
+	}
+	return nil
+}
+
+// IncidentTransition — запись о смене состояния инцидента.
+type IncidentTransition struct {
+	ID         int64          `json:"id" db:"id"`
+	IncidentID int64          `json:"incident_id" db:"incident_id"`
+	From       IncidentStatus `json:"from" db:"from_status"`
+	To         IncidentStatus `json:"to" db:"to_status"`
+	Operator   string         `json:"operator" db:"operator"`
+	Role       string         `json:"role" db:"role"`
+	Comment    string         `json:"comment,omitempty" db:"comment"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,488 @@
+// Package recurrence разбирает и разворачивает правила повторения RRULE
+// (RFC 5545) для повторяющихся инцидентов.
+//
+// Поддерживаются FREQ=DAILY/WEEKLY/MONTHLY/YEARLY с частями INTERVAL, COUNT,
+// UNTIL, BYDAY, BYMONTHDAY, BYMONTH и WKST=MO. Время повторения берётся из
+// DTSTART; остальные части правила (BYSETPOS, BYHOUR и т. п.) не поддерживаются.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// MaxCount ограничивает COUNT, чтобы развёртка правила оставалась дешёвой.
+const MaxCount = 10000
+
+// cyclePeriods — число периодов в 400 годах. Григорианский календарь вместе
+// с днями недели повторяется каждые 400 лет, поэтому правило, у которого
+// столько периодов подряд нет повторений, не даст их уже никогда. Редкие
+// правила вроде FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29 укладываются в этот отрезок.
+var cyclePeriods = map[Frequency]int{
+	Daily:   146097,
+	Weekly:  20871,
+	Monthly: 4800,
+	Yearly:  400,
+}
+
+// maxMonthDays — наибольшее число дней в месяце с учётом високосного года.
+var maxMonthDays = [...]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// WeekdayNum — значение BYDAY: день недели с необязательным порядковым
+// номером внутри месяца (1MO — первый понедельник, -1FR — последняя пятница).
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule — разобранное правило RRULE.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Parse разбирает RRULE вида "FREQ=WEEKLY;BYDAY=SA,SU"; префикс "RRULE:" допускается.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, errors.New("rrule is empty")
+	}
+
+	r := Rule{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return Rule{}, fmt.Errorf("malformed rrule part %q", part)
+		}
+		if seen[key] {
+			return Rule{}, fmt.Errorf("rrule part %s is specified more than once", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			switch r.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = fmt.Errorf("FREQ must be one of DAILY, WEEKLY, MONTHLY, YEARLY")
+			}
+		case "INTERVAL":
+			r.Interval, err = parsePositive(key, value, 1000)
+		case "COUNT":
+			r.Count, err = parsePositive(key, value, MaxCount)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		case "BYMONTH":
+			r.ByMonth, err = parseByMonth(value)
+		case "WKST":
+			if value != "MO" {
+				err = errors.New("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("rrule part %s is not supported", key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return Rule{}, err
+	}
+
+	return r, nil
+}
+
+func (r Rule) validate() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return errors.New("COUNT and UNTIL cannot be combined")
+	}
+
+	for _, d := range r.ByDay {
+		if d.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return errors.New("BYDAY with a position is only allowed in MONTHLY and YEARLY rules")
+		}
+		if d.N < -5 || d.N > 5 {
+			return errors.New("BYDAY position must be between -5 and 5")
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return errors.New("BYMONTHDAY is not allowed in WEEKLY rules")
+	}
+	if len(r.ByDay) > 0 && r.Freq == Yearly && len(r.ByMonth) == 0 {
+		return errors.New("BYDAY in YEARLY rules requires BYMONTH")
+	}
+	if len(r.ByMonthDay) > 0 && !r.monthDayPossible() {
+		return errors.New("BYMONTHDAY never falls in BYMONTH months")
+	}
+
+	return nil
+}
+
+// monthDayPossible сообщает, есть ли хоть один месяц из BYMONTH, в котором
+// бывает один из дней BYMONTHDAY.
+func (r Rule) monthDayPossible() bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		for _, d := range r.ByMonthDay {
+			if d <= maxMonthDays[m] && -d <= maxMonthDays[m] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parsePositive(key, value string, limit int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 || n > limit {
+		return 0, fmt.Errorf("%s must be between 1 and %d", key, limit)
+	}
+	return n, nil
+}
+
+// parseUntil принимает UNTIL в UTC (20261231T235959Z), плавающее время
+// (считается UTC) или дату — тогда она включается целиком.
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, errors.New("UNTIL must be a date or a UTC date-time")
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil || n == 0 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", item)
+			}
+		}
+
+		days = append(days, WeekdayNum{N: n, Day: day})
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		d, err := strconv.Atoi(item)
+		if err != nil || d == 0 || d < -31 || d > 31 {
+			return nil, fmt.Errorf("invalid BYMONTHDAY value %q", item)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func parseByMonth(value string) ([]time.Month, error) {
+	var months []time.Month
+	for _, item := range strings.Split(value, ",") {
+		m, err := strconv.Atoi(item)
+		if err != nil || m < 1 || m > 12 {
+			return nil, fmt.Errorf("invalid BYMONTH value %q", item)
+		}
+		months = append(months, time.Month(m))
+	}
+	return months, nil
+}
+
+// Each вызывает fn для начал повторений не раньше from в порядке времени,
+// пока fn возвращает true или правило не исчерпано. Время суток и часовой
+// пояс повторений берутся из dtstart.
+func (r Rule) Each(dtstart, from time.Time, fn func(start time.Time) bool) {
+	interval := max(r.Interval, 1)
+
+	period := 0
+	// без COUNT повторения до from можно не перебирать
+	if r.Count == 0 && from.After(dtstart) {
+		period = r.periodsBetween(dtstart, from) / interval * interval
+	}
+
+	count := 0
+	for empty := 0; empty < cyclePeriods[r.Freq]; period += interval {
+		starts := r.candidates(dtstart, period)
+		if len(starts) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+
+		for _, t := range starts {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if t.Before(from) {
+				continue
+			}
+			if !fn(t) {
+				return
+			}
+		}
+	}
+}
+
+// periodsBetween считает целые периоды правила от периода dtstart до периода t.
+func (r Rule) periodsBetween(dtstart, t time.Time) int {
+	t = t.In(dtstart.Location())
+	y1, m1, _ := dtstart.Date()
+	y2, m2, _ := t.Date()
+
+	switch r.Freq {
+	case Daily:
+		return civilDays(dtstart, t)
+	case Weekly:
+		return civilDays(weekStart(dtstart), t) / 7
+	case Monthly:
+		return (y2-y1)*12 + int(m2) - int(m1)
+	default:
+		return y2 - y1
+	}
+}
+
+// candidates возвращает повторения period-го периода правила по порядку.
+func (r Rule) candidates(dtstart time.Time, period int) []time.Time {
+	y, m, d := dtstart.Date()
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		day := date(y, m, d+period)
+		if r.matchesMonth(day.Month()) && r.matchesMonthDay(day) && r.matchesWeekday(day.Weekday()) {
+			days = append(days, day)
+		}
+	case Weekly:
+		monday := weekStart(dtstart).AddDate(0, 0, 7*period)
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []WeekdayNum{{Day: dtstart.Weekday()}}
+		}
+		for _, wd := range byDay {
+			day := monday.AddDate(0, 0, (int(wd.Day)+6)%7)
+			if r.matchesMonth(day.Month()) {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		first := date(y, m+time.Month(period), 1)
+		if r.matchesMonth(first.Month()) {
+			days = r.monthDays(first, d)
+		}
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{m}
+		}
+		for _, month := range months {
+			days = append(days, r.monthDays(date(y+period, month, 1), d)...)
+		}
+	}
+
+	hh, mm, ss := dtstart.Clock()
+	starts := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		dy, dm, dd := day.Date()
+		starts = append(starts, time.Date(dy, dm, dd, hh, mm, ss, 0, dtstart.Location()))
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	return dedupe(starts)
+}
+
+// monthDays возвращает дни месяца first по BYMONTHDAY и BYDAY; если обе части
+// заданы — их пересечение, если ни одной — день dtstartDay.
+func (r Rule) monthDays(first time.Time, dtstartDay int) []time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+
+	var byMonthDay, byDay map[int]bool
+	if len(r.ByMonthDay) > 0 {
+		byMonthDay = map[int]bool{}
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = last + 1 + d
+			}
+			if d >= 1 && d <= last {
+				byMonthDay[d] = true
+			}
+		}
+	}
+	if len(r.ByDay) > 0 {
+		byDay = map[int]bool{}
+		for _, wd := range r.ByDay {
+			for _, d := range weekdayDays(first, last, wd) {
+				byDay[d] = true
+			}
+		}
+	}
+
+	var days []time.Time
+	for d := 1; d <= last; d++ {
+		ok := d == dtstartDay
+		switch {
+		case byMonthDay != nil && byDay != nil:
+			ok = byMonthDay[d] && byDay[d]
+		case byMonthDay != nil:
+			ok = byMonthDay[d]
+		case byDay != nil:
+			ok = byDay[d]
+		}
+		if ok {
+			days = append(days, first.AddDate(0, 0, d-1))
+		}
+	}
+	return days
+}
+
+// weekdayDays возвращает дни месяца, попадающие под значение BYDAY.
+func weekdayDays(first time.Time, last int, wd WeekdayNum) []int {
+	var days []int
+	for d := 1 + (int(wd.Day)-int(first.Weekday())+7)%7; d <= last; d += 7 {
+		days = append(days, d)
+	}
+
+	switch {
+	case wd.N > 0 && wd.N <= len(days):
+		return days[wd.N-1 : wd.N]
+	case wd.N < 0 && -wd.N <= len(days):
+		return days[len(days)+wd.N : len(days)+wd.N+1]
+	case wd.N != 0:
+		return nil
+	}
+	return days
+}
+
+func (r Rule) matchesMonth(m time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, month := range r.ByMonth {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := day.AddDate(0, 1, -day.Day()).Day()
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || last+1+d == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesWeekday(w time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day == w {
+			return true
+		}
+	}
+	return false
+}
+
+// date возвращает полночь календарной даты в UTC; нормализует выход за
+// границы месяца, как time.Date.
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart возвращает понедельник недели t как календарную дату.
+func weekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return date(y, m, d-(int(t.Weekday())+6)%7)
+}
+
+// civilDays считает календарные дни между датами a и b без учёта перехода на летнее время.
+func civilDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return int(date(by, bm, bd).Sub(date(ay, am, ad)).Hours() / 24)
+}
+
+func dedupe(starts []time.Time) []time.Time {
+	out := starts[:0]
+	for i, t := range starts {
+		if i == 0 || !t.Equal(starts[i-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testTable := []struct {
+		name        string
+		input       string
+		expectedErr bool
+	}{
+		{name: "Weekly", input: "FREQ=WEEKLY;BYDAY=SA,SU"},
+		{name: "With prefix", input: "RRULE:FREQ=DAILY;INTERVAL=2;COUNT=10"},
+		{name: "Monthly by position", input: "FREQ=MONTHLY;BYDAY=1MO"},
+		{name: "Until date", input: "FREQ=DAILY;UNTIL=20261231"},
+		{name: "Missing FREQ", input: "BYDAY=MO", expectedErr: true},
+		{name: "Count and until", input: "FREQ=DAILY;COUNT=3;UNTIL=20261231", expectedErr: true},
+		{name: "Unsupported part", input: "FREQ=DAILY;BYHOUR=10", expectedErr: true},
+		{name: "Hourly", input: "FREQ=HOURLY", expectedErr: true},
+		{name: "Weekly by position", input: "FREQ=WEEKLY;BYDAY=1MO", expectedErr: true},
+		{name: "Bad weekday", input: "FREQ=WEEKLY;BYDAY=XX", expectedErr: true},
+		{name: "Leap day", input: "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29"},
+		{name: "Day not in month", input: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30,-31", expectedErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Parse(testCase.input)
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRule_Each(t *testing.T) {
+	// 2026-10-03 — суббота
+	dtstart := time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name     string
+		rrule    string
+		from     time.Time
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "Weekly weekend",
+			rrule:    "FREQ=WEEKLY;BYDAY=SA,SU",
+			from:     dtstart,
+			limit:    4,
+			expected: []string{"2026-10-03", "2026-10-04", "2026-10-10", "2026-10-11"},
+		},
+		{
+			name:     "Every other day with count",
+			rrule:    "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			from:     dtstart,
+			limit:    10,
+			expected: []string{"2026-10-03", "2026-10-05", "2026-10-07"},
+		},
+		{
+			name:     "First Monday of month",
+			rrule:    "FREQ=MONTHLY;BYDAY=1MO",
+			from:     dtstart,
+			limit:    3,
+			expected: []string{"2026-10-05", "2026-11-02", "2026-12-07"},
+		},
+		{
+			name:     "Last day of month",
+			rrule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			from:     dtstart,
+			limit:    3,
+			expected: []string{"2026-10-31", "2026-11-30", "2026-12-31"},
+		},
+		{
+			name:     "Skips to from",
+			rrule:    "FREQ=WEEKLY",
+			from:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			limit:    2,
+			expected: []string{"2027-01-02", "2027-01-09"},
+		},
+		{
+			name:     "Until",
+			rrule:    "FREQ=DAILY;UNTIL=20261004T100000Z",
+			from:     dtstart,
+			limit:    10,
+			expected: []string{"2026-10-03", "2026-10-04"},
+		},
+		{
+			name:     "Yearly leap day",
+			rrule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+			from:     dtstart,
+			limit:    2,
+			expected: []string{"2028-02-29", "2032-02-29"},
+		},
+		{
+			name:     "Daily leap day",
+			rrule:    "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			from:     dtstart,
+			limit:    2,
+			expected: []string{"2028-02-29", "2032-02-29"},
+		},
+		{
+			name:     "Leap day across century",
+			rrule:    "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			from:     time.Date(2097, 1, 1, 0, 0, 0, 0, time.UTC),
+			limit:    1,
+			expected: []string{"2104-02-29"},
+		},
+		{
+			name:  "Never matches",
+			rrule: "FREQ=DAILY;INTERVAL=7;BYDAY=MO",
+			from:  dtstart,
+			limit: 1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := Parse(testCase.rrule)
+			assert.NoError(t, err)
+
+			var got []string
+			rule.Each(dtstart, testCase.from, func(start time.Time) bool {
+				assert.Equal(t, 10, start.Hour())
+				got = append(got, start.Format("2006-01-02"))
+				return len(got) < testCase.limit
+			})
+
+			assert.Equal(t, testCase.expected, got)
+		})
+	}
+}
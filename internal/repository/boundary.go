@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type BoundaryRepo struct {
+	db *sqlx.DB
+}
+
+func NewBoundaryPostgres(db *sqlx.DB) *BoundaryRepo {
+	return &BoundaryRepo{db: db}
+}
+
+const boundaryColumns = `
+			id,
+			parent_id,
+			level,
+			code,
+			name,
+			created_at`
+
+func (r *BoundaryRepo) ListBoundaries(ctx context.Context, filter models.BoundaryFilter) ([]models.Boundary, error) {
+	query := `
+		SELECT` + boundaryColumns + `
+		FROM boundaries
+		WHERE ($1 = '' OR level = $1)
+			AND ($2::bigint IS NULL OR parent_id = $2)
+		ORDER BY cardinality(path), name, id
+	`
+
+	boundaries := []models.Boundary{}
+	if err := r.db.SelectContext(ctx, &boundaries, query, filter.Level, filter.ParentID); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return boundaries, nil
+}
+
+func (r *BoundaryRepo) GetBoundary(ctx context.Context, id int64) (models.Boundary, error) {
+	query := `
+		SELECT` + boundaryColumns + `,
+			ST_AsGeoJSON(geom) AS geometry
+		FROM boundaries
+		WHERE id = $1
+	`
+
+	var b models.Boundary
+	err := r.db.GetContext(ctx, &b, query, id)
+	return b, err
+}
+
+// ImportBoundaries загружает границы в одной транзакции. Родители
+// вставляются раньше детей; родитель ищется сначала среди загружаемых
+// границ, затем среди уже сохранённых.
+func (r *BoundaryRepo) ImportBoundaries(ctx context.Context, boundaries []models.BoundaryImport) ([]int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	order := make([]int, len(boundaries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return boundaries[order[a]].Level.Contains(boundaries[order[b]].Level)
+	})
+
+	type saved struct {
+		id    int64
+		level models.BoundaryLevel
+		path  pq.Int64Array
+	}
+	byCode := map[string]saved{}
+
+	ids := make([]int64, len(boundaries))
+	for _, i := range order {
+		b := boundaries[i]
+
+		var (
+			parentID *int64
+			path     pq.Int64Array
+		)
+		if b.ParentCode != "" {
+			parent, ok := byCode[b.ParentCode]
+			if !ok {
+				err := tx.QueryRowxContext(ctx,
+					`SELECT id, level, path FROM boundaries WHERE code = $1`, b.ParentCode,
+				).Scan(&parent.id, &parent.level, &parent.path)
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, fmt.Errorf("%w: boundary %s: unknown parent %s", models.ErrValidation, b.Code, b.ParentCode)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !parent.level.Contains(b.Level) {
+				return nil, fmt.Errorf("%w: boundary %s: %s cannot contain %s", models.ErrValidation, b.Code, parent.level, b.Level)
+			}
+			parentID = &parent.id
+			path = parent.path
+		}
+
+		var id int64
+		if err := tx.GetContext(ctx, &id, `SELECT nextval(pg_get_serial_sequence('boundaries', 'id'))`); err != nil {
+			return nil, err
+		}
+		path = append(append(pq.Int64Array{}, path...), id)
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO boundaries (id, parent_id, level, code, name, geom, path)
+			VALUES ($1, $2, $3, $4, $5, ST_Multi(ST_GeomFromGeoJSON($6::text))::geography, $7)
+		`, id, parentID, b.Level, b.Code, b.Name, string(b.Geometry), path)
+		if pgErrorCode(err) == pgUniqueViolation {
+			return nil, fmt.Errorf("%w: %s", models.ErrBoundaryExists, b.Code)
+		}
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		byCode[b.Code] = saved{id: id, level: b.Level, path: path}
+		ids[i] = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// DeleteBoundary удаляет границу; пока на неё ссылаются инциденты или
+// вложенные границы, возвращает models.ErrBoundaryInUse.
+func (r *BoundaryRepo) DeleteBoundary(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM boundaries WHERE id = $1`, id)
+	if pgErrorCode(err) == pgForeignKeyViolation {
+		return models.ErrBoundaryInUse
+	}
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// MissingBoundaries возвращает идентификаторы из ids, которых нет в справочнике.
+func (r *BoundaryRepo) MissingBoundaries(ctx context.Context, ids models.BoundaryIDs) ([]int64, error) {
+	query := `
+		SELECT u.id
+		FROM unnest($1::bigint[]) AS u(id)
+		LEFT JOIN boundaries b ON b.id = u.id
+		WHERE b.id IS NULL
+		ORDER BY u.id
+	`
+
+	missing := []int64{}
+	err := r.db.SelectContext(ctx, &missing, query, ids)
+	return missing, err
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -19,101 +21,300 @@ func NewIncidentPostgres(db *sqlx.DB) *IncidentRepo {
 	return &IncidentRepo{db: db}
 }
 
-func (r *IncidentRepo) CreateIncident(req models.IncidentRequest) error {
+// incidentZoneGeom — геометрия зоны из запроса либо объединение
+// административных границ $16.
+const incidentZoneGeom = `COALESCE(
+			ST_GeomFromGeoJSON($3::text),
+			(SELECT ST_Union(geom::geometry) FROM boundaries WHERE id = ANY($16::bigint[]))
+		)`
+
+// Для полигональных, линейных и заданных границами зон location хранит
+// центроид зоны, а radius_meters пуст; buffer_meters заполнен только у линий.
+const incidentZoneValues = `
+		COALESCE(
+			ST_Centroid(` + incidentZoneGeom + `)::geography,
+			ST_MakePoint($4, $5)::geography
+		),
+		` + incidentZoneGeom + `::geography,
+		NULLIF($6::integer, 0)`
+
+// liveCondition — инцидент действует: опубликован, включён и текущий момент
+// внутри окна действия, а у повторяющегося — ещё и внутри текущего повторения.
+const liveCondition = `is_active
+			AND status = 'published'
+			AND deleted_at IS NULL
+			AND (starts_at IS NULL OR starts_at <= now())
+			AND (ends_at IS NULL OR ends_at > now())
+			AND (recurrence IS NULL OR COALESCE(occurrence_starts_at <= now() AND occurrence_ends_at > now(), FALSE))`
+
+const incidentColumns = `
+			id,
+			type,
+			severity,
+			description,
+			ST_Y(location::geometry) AS latitude,
+			ST_X(location::geometry) AS longitude,
+			COALESCE(radius_meters, 0) AS radius_meters,
+			COALESCE(
+				round(incident_radius(radius_schedule, COALESCE(starts_at, created_at), now()))::integer,
+				0
+			) AS effective_radius_meters,
+			radius_schedule,
+			ST_AsGeoJSON(zone) AS geometry,
+			COALESCE(buffer_meters, 0) AS buffer_meters,
+			(
+				SELECT json_agg(boundary_id ORDER BY boundary_id)
+				FROM incident_boundaries
+				WHERE incident_id = incidents.id
+			) AS boundary_ids,
+			tiers,
+			exclusions,
+			is_active,
+			starts_at,
+			ends_at,
+			recurrence,
+			occurrence_starts_at,
+			occurrence_ends_at,
+			status,
+			(` + liveCondition + `) AS live,
+			created_at,
+			updated_at,
+			deleted_at,
+			deleted_by,
+			merged_into,
+			version`
+
+// CreateIncident создаёт инцидент и возвращает его в сохранённом виде.
+func (r *IncidentRepo) CreateIncident(req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	created, err := createIncidentTx(tx, req, meta)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return created, nil
+}
+
+// CreateIncidents создаёт пачку инцидентов в одной транзакции. rowErrs[i] содержит
+// ошибку i-го инцидента. В частичном режиме каждый инцидент пишется под своей
+// точкой сохранения и ошибка не откатывает остальные; иначе при первой ошибке
+// откатывается вся пачка и ids пуст.
+func (r *IncidentRepo) CreateIncidents(
+	reqs []models.IncidentRequest,
+	meta models.ChangeMeta,
+	partial bool,
+) (ids []int64, rowErrs []error, err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	ids = make([]int64, len(reqs))
+	rowErrs = make([]error, len(reqs))
+
+	for i, req := range reqs {
+		if !partial {
+			ids[i], err = createIncidentIDTx(tx, req, meta)
+			if err != nil {
+				if rowErrs[i] = importRowError(req, err); rowErrs[i] == nil {
+					return nil, nil, err
+				}
+				return nil, rowErrs, nil
+			}
+			continue
+		}
+
+		if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+			return nil, nil, err
+		}
+
+		ids[i], err = createIncidentIDTx(tx, req, meta)
+		if err != nil {
+			if rowErrs[i] = importRowError(req, err); rowErrs[i] == nil {
+				return nil, nil, err
+			}
+		}
+
+		release := "RELEASE SAVEPOINT import_row"
+		if rowErrs[i] != nil {
+			release = "ROLLBACK TO SAVEPOINT import_row"
+		}
+		if _, err := tx.Exec(release); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return ids, rowErrs, nil
+}
+
+// importRowError переводит ошибку базы при вставке строки импорта в ошибку
+// валидации, понятную клиенту. nil — ошибка не связана с данными строки, и
+// импорт прерывается.
+func importRowError(req models.IncidentRequest, err error) error {
+	log.Println(err)
+
+	code := pgErrorCode(err)
+	switch {
+	case code == pgForeignKeyViolation:
+		return fmt.Errorf("%w: unknown incident type %q", models.ErrValidation, req.Type)
+	case code == pgCheckViolation, code == pgInternalError, strings.HasPrefix(code, pgDataException):
+		return fmt.Errorf("%w: invalid incident data", models.ErrValidation)
+	}
+	return nil
+}
+
+func createIncidentIDTx(tx *sqlx.Tx, req models.IncidentRequest, meta models.ChangeMeta) (int64, error) {
+	created, err := createIncidentTx(tx, req, meta)
+	return created.ID, err
+}
+
+// createIncidentTx заводит инцидент черновиком: оповещать он начнёт только
+// после публикации администратором.
+func createIncidentTx(tx *sqlx.Tx, req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error) {
 	query := `
 		INSERT INTO incidents 
-		(type, description, location, radius_meters, is_active, created_at, updated_at)
+		(type, description, location, zone, radius_meters, is_active, created_at, updated_at, starts_at, ends_at, severity, tiers, exclusions, buffer_meters, radius_schedule, recurrence, occurrence_starts_at, occurrence_ends_at, status, schedule_state)
 		VALUES (
 		$1, 
-		$2, 
-		ST_MakePoint($3, $4)::geography, 
-		$5,
-		$6,
+		$2, ` + incidentZoneValues + `,
 		$7,
-		$8)
+		$8,
+		$9,
+		$10,
+		$11,
+		$12,
+		$13,
+		$14,
+		NULLIF($15::integer, 0),
+		$17,
+		$18,
+		$19,
+		$20,
+		$21,
+		-- событие о старте планировщик отправит, когда инцидент опубликуют
+		-- и наступит его окно действия
+		'pending')
 		RETURNING id
 	`
 
 	now := time.Now()
+	occurrenceStart, occurrenceEnd := occurrenceWindow(req.Recurrence, req.StartsAt, req.EndsAt, nil, now)
 
 	var id int64
-	err := r.db.QueryRowx(
+	err := tx.QueryRowx(
 		query,
 		req.Type,
 		req.Description,
+		geometryArg(req.Geometry),
 		req.Longitude,
 		req.Latitude,
-		req.RadiusMeters,
+		radiusArg(req),
 		req.Active,
 		now,
 		now,
+		req.StartsAt,
+		req.EndsAt,
+		req.Severity.OrDefault(),
+		req.Tiers,
+		req.Exclusions,
+		bufferArg(req),
+		req.BoundaryIDs,
+		req.RadiusSchedule,
+		req.Recurrence,
+		occurrenceStart,
+		occurrenceEnd,
+		models.StatusDraft,
 	).Scan(&id)
 
 	if err != nil {
-		log.Println(err)
-		return err
+		return models.IncidentResponse{}, err
 	}
 
-	return nil
+	if err := setIncidentBoundariesTx(tx, id, req.BoundaryIDs); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	after, err := lockIncidentTx(tx, id)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	if err := insertRevision(tx, id, models.RevisionCreate, meta, nil, &after); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return after, nil
 }
 
-func (r *IncidentRepo) GetAllIncidents(limit, offset int) ([]models.IncidentResponse, error) {
+// GetAllIncidents возвращает страницу инцидентов. Для сортировок по времени
+// выборка идёт по ключу (created_at, id) или (updated_at, id), что не
+// пропускает и не дублирует строки при вставках между запросами страниц.
+func (r *IncidentRepo) GetAllIncidents(filter models.IncidentFilter) (models.IncidentPage, error) {
+	var args queryArgs
+	where, orderBy := incidentFilterSQL(filter, &args)
+	whereArgs := len(args)
+
+	// лишняя строка нужна только чтобы узнать, есть ли следующая страница
 	query := `
-		SELECT
-			type,
-			description,
-			ST_Y(location::geometry) AS latitude,
-			ST_X(location::geometry) AS longitude,
-			radius_meters,
-			is_active,
-			created_at,
-			updated_at
+		SELECT` + incidentColumns + `
 		FROM incidents
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		WHERE ` + cursorSQL(where, filter, &args) + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + args.add(filter.Limit+1) + ` OFFSET ` + args.add(filter.Offset)
 
-	rows, err := r.db.Query(query, limit, offset)
-	if err != nil {
-		return nil, err
+	rows := []models.IncidentResponse{}
+	if err := r.db.Select(&rows, query, args...); err != nil {
+		log.Println(err)
+		return models.IncidentPage{}, err
 	}
-	defer rows.Close()
 
-	var resp []models.IncidentResponse
-
-	for rows.Next() {
-		var inc models.IncidentResponse
+	var page models.IncidentPage
+	if len(rows) > filter.Limit {
+		page.HasMore = true
+		rows = rows[:filter.Limit]
+	}
+	page.Items = rows
 
-		if err := rows.Scan(
-			&inc.Type,
-			&inc.Description,
-			&inc.Latitude,
-			&inc.Longitude,
-			&inc.RadiusMeters,
-			&inc.Active,
-			&inc.CreatedAt,
-			&inc.UpdatedAt,
-		); err != nil {
-			return nil, err
+	if page.HasMore && len(rows) > 0 && models.SupportsCursor(filter.Sort) {
+		last := rows[len(rows)-1]
+		cursor := models.Cursor{Sort: filter.Sort, Time: last.CreatedAt, ID: last.ID}
+		if filter.Sort == models.SortUpdatedDesc || filter.Sort == models.SortUpdatedAsc {
+			cursor.Time = last.UpdatedAt
 		}
+		page.NextCursor = cursor.Encode()
+	}
 
-		resp = append(resp, inc)
+	if filter.WithTotal {
+		var total int64
+		countQuery := `SELECT COUNT(*) FROM incidents WHERE ` + where
+		if err := r.db.Get(&total, countQuery, args[:whereArgs]...); err != nil {
+			log.Println(err)
+			return models.IncidentPage{}, err
+		}
+		page.Total = &total
 	}
 
-	return resp, rows.Err()
+	return page, nil
 }
 
 func (r *IncidentRepo) GetIncidentById(id int) (models.IncidentResponse, error) {
 	query := `
-		SELECT
-			type,
-			description,
-			ST_Y(location::geometry) AS latitude,
-			ST_X(location::geometry) AS longitude,
-			radius_meters,
-			is_active,
-			created_at,
-			updated_at
+		SELECT` + incidentColumns + `
 		FROM incidents
 		WHERE id = $1
 	`
@@ -134,6 +335,7 @@ func (r *IncidentRepo) GetIncidentById(id int) (models.IncidentResponse, error)
 func (r *IncidentRepo) UpdateIncident(
 	id int,
 	req models.IncidentRequest,
+	meta models.ChangeMeta,
 ) (models.IncidentResponse, error) {
 
 	query := `
@@ -141,69 +343,257 @@ func (r *IncidentRepo) UpdateIncident(
 		SET
 			type = $1,
 			description = $2,
-			location = ST_MakePoint($3, $4)::geography,
-			radius_meters = $5,
-			is_active = $6,
-			updated_at = NOW()
-		WHERE id = $7
-		RETURNING
-			type,
-			description,
-			ST_Y(location::geometry) AS latitude,
-			ST_X(location::geometry) AS longitude,
-			radius_meters,
-			is_active,
-			created_at,
-			updated_at
+			(location, zone, radius_meters) = (SELECT ` + incidentZoneValues + `),
+			is_active = $7,
+			starts_at = $9,
+			ends_at = $10,
+			severity = $11,
+			tiers = $13,
+			exclusions = $14,
+			buffer_meters = NULLIF($15::integer, 0),
+			radius_schedule = $17,
+			recurrence = $18,
+			occurrence_starts_at = $19,
+			occurrence_ends_at = $20,
+			-- перенос окна действия или повторения заново «взводит» планировщик
+			schedule_state = CASE
+				WHEN $9::timestamptz > now() THEN 'pending'
+				WHEN $19::timestamptz > now() THEN 'pending'
+				WHEN schedule_state = 'ended' AND $7 AND ($10::timestamptz IS NULL OR $10::timestamptz > now()) THEN 'pending'
+				ELSE schedule_state
+			END,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $8
+			AND ($12::bigint = 0 OR version = $12)
+		RETURNING` + incidentColumns + `
 	`
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockIncidentTx(tx, int64(id))
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	if before.DeletedAt != nil {
+		return models.IncidentResponse{}, sql.ErrNoRows
+	}
+
+	if !versionMatches(before, meta) {
+		return models.IncidentResponse{}, models.ErrVersionConflict
+	}
+
+	if err := models.CanEdit(before.Status, meta.Role); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	// связи меняются до UPDATE, чтобы RETURNING уже увидел новые границы
+	if err := setIncidentBoundariesTx(tx, int64(id), req.BoundaryIDs); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	var occurrenceStart, occurrenceEnd *time.Time
+	if req.Recurrence != nil {
+		cancelled, err := cancelledOccurrences(context.Background(), tx, int64(id))
+		if err != nil {
+			return models.IncidentResponse{}, err
+		}
+		occurrenceStart, occurrenceEnd = occurrenceWindow(req.Recurrence, req.StartsAt, req.EndsAt, cancelled, time.Now())
+	}
+
 	var incident models.IncidentResponse
 
-	err := r.db.Get(
+	err = tx.Get(
 		&incident,
 		query,
 		req.Type,
 		req.Description,
-		req.Latitude,
+		geometryArg(req.Geometry),
 		req.Longitude,
-		req.RadiusMeters,
+		req.Latitude,
+		radiusArg(req),
 		req.Active,
 		id,
+		req.StartsAt,
+		req.EndsAt,
+		req.Severity.OrDefault(),
+		meta.IfVersion,
+		req.Tiers,
+		req.Exclusions,
+		bufferArg(req),
+		req.BoundaryIDs,
+		req.RadiusSchedule,
+		req.Recurrence,
+		occurrenceStart,
+		occurrenceEnd,
 	)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IncidentResponse{}, models.ErrVersionConflict
+		}
+		return models.IncidentResponse{}, err
+	}
+
+	if err := insertRevision(tx, int64(id), models.RevisionUpdate, meta, &before, &incident); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return models.IncidentResponse{}, err
 	}
 
 	return incident, nil
 }
 
-func (r *IncidentRepo) DeleteIncident(id int) error {
+func (r *IncidentRepo) DeleteIncident(id int, meta models.ChangeMeta) error {
 	query := `
 		UPDATE incidents
 		SET 
 			is_active = false,
-			updated_at = NOW()
-		WHERE id = $1 AND is_active = true
-	`
+			deleted_at = NOW(),
+			deleted_by = $3,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+			AND ($2::bigint = 0 OR version = $2)
+		RETURNING` + incidentColumns
 
-	result, err := r.db.Exec(query, id)
+	tx, err := r.db.Beginx()
 	if err != nil {
-		log.Println(err)
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	before, err := lockIncidentTx(tx, int64(id))
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	if rowsAffected == 0 {
+	if before.DeletedAt != nil {
 		return sql.ErrNoRows
 	}
 
-	return nil
+	if !versionMatches(before, meta) {
+		return models.ErrVersionConflict
+	}
+
+	// удаление опубликованного инцидента снимает оповещения без согласования
+	if err := models.CanEdit(before.Status, meta.Role); err != nil {
+		return err
+	}
+
+	var after models.IncidentResponse
+	if err := tx.Get(&after, query, id, meta.IfVersion, meta.Operator); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if err := insertRevision(tx, int64(id), models.RevisionDelete, meta, &before, &after); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RestoreIncident снимает пометку об удалении и снова включает инцидент.
+func (r *IncidentRepo) RestoreIncident(id int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	query := `
+		UPDATE incidents
+		SET
+			is_active = true,
+			deleted_at = NULL,
+			deleted_by = NULL,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING` + incidentColumns
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockIncidentTx(tx, int64(id))
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	if before.DeletedAt == nil {
+		return models.IncidentResponse{}, models.ErrIncidentNotDeleted
+	}
+
+	if !versionMatches(before, meta) {
+		return models.IncidentResponse{}, models.ErrVersionConflict
+	}
+
+	// восстановленный опубликованный инцидент снова начинает оповещать
+	if err := models.CanEdit(before.Status, meta.Role); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	var after models.IncidentResponse
+	if err := tx.Get(&after, query, id); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := insertRevision(tx, int64(id), models.RevisionRestore, meta, &before, &after); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return after, nil
+}
+
+// PurgeIncident окончательно удаляет инцидент вместе с историей и связями с
+// проверками локаций. Допустимо только для инцидентов, удалённых раньше
+// чем retention назад.
+func (r *IncidentRepo) PurgeIncident(ctx context.Context, id int, retention time.Duration) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	inc, err := lockIncidentTx(tx, int64(id))
+	if err != nil {
+		return err
+	}
+
+	if inc.DeletedAt == nil {
+		return models.ErrIncidentNotDeleted
+	}
+	if time.Since(*inc.DeletedAt) < retention {
+		return models.ErrRetentionPeriod
+	}
+
+	for _, query := range []string{
+		`DELETE FROM location_check_incidents WHERE incident_id = $1`,
+		`DELETE FROM incident_revisions WHERE incident_id = $1`,
+		`DELETE FROM incident_transitions WHERE incident_id = $1`,
+		`DELETE FROM incidents WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (r *IncidentRepo) GetDangerStats(
@@ -231,13 +621,208 @@ func (r *IncidentRepo) GetDangerStats(
 }
 
 func (r *IncidentRepo) GetActiveIncidents(ctx context.Context) ([]models.IncidentResponse, error) {
-	const query = `
-        SELECT id, title, status, created_at
-        FROM incidents
-        WHERE status = 'active'
-        ORDER BY created_at DESC
-    `
+	query := `
+		SELECT` + incidentColumns + `
+		FROM incidents
+		WHERE ` + liveCondition + `
+		ORDER BY created_at DESC
+	`
 	var incidents []models.IncidentResponse
 	err := r.db.SelectContext(ctx, &incidents, query)
 	return incidents, err
 }
+
+// StartScheduledIncidents переводит в состояние started инциденты, окно действия
+// или текущее повторение которых уже наступило, и возвращает их. Переход атомарный, поэтому событие
+// о старте будет отправлено один раз даже при нескольких экземплярах сервиса.
+func (r *IncidentRepo) StartScheduledIncidents(ctx context.Context) ([]models.IncidentEvent, error) {
+	query := `
+		UPDATE incidents
+		SET schedule_state = 'started'
+		WHERE schedule_state = 'pending'
+			AND ` + liveCondition + `
+		RETURNING` + incidentEventColumns
+
+	var events []models.IncidentEvent
+	err := r.db.SelectContext(ctx, &events, query)
+	return events, err
+}
+
+// schedulerOperator — оператор в ревизиях, которые пишет планировщик.
+const schedulerOperator = "scheduler"
+
+// EndExpiredIncidents выключает инциденты, у которых истекло окно действия.
+// Конец серии повторений обрабатывает AdvanceOccurrences.
+func (r *IncidentRepo) EndExpiredIncidents(ctx context.Context) ([]models.IncidentEvent, error) {
+	const query = `
+		SELECT id
+		FROM incidents
+		WHERE schedule_state <> 'ended'
+			AND recurrence IS NULL
+			AND ends_at <= now()
+		FOR UPDATE SKIP LOCKED`
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids, query); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	var events []models.IncidentEvent
+	for _, id := range ids {
+		before, err := expireIncidentTx(ctx, tx, id)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		events = append(events, models.IncidentEvent{
+			ID:        before.ID,
+			Type:      before.Type,
+			Severity:  before.Severity,
+			Latitude:  before.Latitude,
+			Longitude: before.Longitude,
+			StartsAt:  before.StartsAt,
+			EndsAt:    before.EndsAt,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// expireIncidentTx выключает инцидент, чьё окно действия или серия повторений
+// закончились, пишет ревизию от имени планировщика и возвращает состояние до.
+func expireIncidentTx(ctx context.Context, tx *sqlx.Tx, id int64) (models.IncidentResponse, error) {
+	query := `
+		UPDATE incidents
+		SET
+			occurrence_starts_at = NULL,
+			occurrence_ends_at = NULL,
+			schedule_state = 'ended',
+			is_active = false,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING` + incidentColumns
+
+	before, err := lockIncidentTx(tx, id)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	var after models.IncidentResponse
+	if err := tx.GetContext(ctx, &after, query, id); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	meta := models.ChangeMeta{Operator: schedulerOperator}
+	if err := insertRevision(tx, id, models.RevisionExpire, meta, &before, &after); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return before, nil
+}
+
+// incidentEventColumns — для повторяющегося инцидента окно события — его
+// текущее повторение.
+const incidentEventColumns = `
+			id,
+			type,
+			severity,
+			ST_Y(location::geometry) AS latitude,
+			ST_X(location::geometry) AS longitude,
+			COALESCE(occurrence_starts_at, starts_at) AS starts_at,
+			COALESCE(occurrence_ends_at, ends_at) AS ends_at`
+
+// geometryArg передаёт GeoJSON в запрос текстом: []byte драйвер отправил бы как bytea.
+func geometryArg(raw json.RawMessage) any {
+	if !models.HasGeometry(raw) {
+		return nil
+	}
+	return string(raw)
+}
+
+func radiusArg(req models.IncidentRequest) int {
+	if models.HasGeometry(req.Geometry) || len(req.BoundaryIDs) > 0 {
+		return 0
+	}
+	if len(req.Tiers) > 0 {
+		return req.Tiers.Outer()
+	}
+	if len(req.RadiusSchedule) > 0 {
+		return req.RadiusSchedule.Max()
+	}
+	return req.RadiusMeters
+}
+
+func bufferArg(req models.IncidentRequest) int {
+	if !models.IsLine(req.Geometry) {
+		return 0
+	}
+	if len(req.Tiers) > 0 {
+		return req.Tiers.Outer()
+	}
+	return req.BufferMeters
+}
+
+// setIncidentBoundariesTx заменяет границы, которыми задана зона инцидента.
+func setIncidentBoundariesTx(tx *sqlx.Tx, id int64, ids models.BoundaryIDs) error {
+	if _, err := tx.Exec(`DELETE FROM incident_boundaries WHERE incident_id = $1`, id); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO incident_boundaries (incident_id, boundary_id)
+		SELECT $1, unnest($2::bigint[])
+	`, id, ids)
+	return err
+}
+
+// ForEachIncident построчно читает инциденты и передаёт их в fn, не собирая
+// всю выборку в памяти. Ошибка fn прерывает чтение.
+func (r *IncidentRepo) ForEachIncident(
+	ctx context.Context,
+	filter models.IncidentFilter,
+	fn func(inc models.IncidentResponse) error,
+) error {
+	var args queryArgs
+	where, orderBy := incidentFilterSQL(filter, &args)
+
+	query := `
+		SELECT` + incidentColumns + `
+		FROM incidents
+		WHERE ` + cursorSQL(where, filter, &args) + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + args.add(limitArg(filter.Limit)) + ` OFFSET ` + args.add(filter.Offset)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var inc models.IncidentResponse
+		if err := rows.StructScan(&inc); err != nil {
+			return err
+		}
+
+		if err := fn(inc); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
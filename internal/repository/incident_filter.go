@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// queryArgs собирает позиционные параметры запроса; значения фильтров
+// никогда не подставляются в текст SQL.
+type queryArgs []any
+
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// zoneDistanceSQL — расстояние в метрах от точки до зоны инцидента (0 внутри зоны).
+func zoneDistanceSQL(point string) string {
+	return `CASE
+				WHEN zone IS NULL THEN GREATEST(ST_Distance(location, ` + point + `) - radius_meters, 0)
+				ELSE ST_Distance(zone, ` + point + `)
+			END`
+}
+
+// incidentFilterSQL строит условие WHERE и ORDER BY для выборки инцидентов.
+// Курсор filter.After в условие не входит: его добавляет cursorSQL, чтобы
+// общее число записей считалось без него.
+func incidentFilterSQL(filter models.IncidentFilter, args *queryArgs) (where, orderBy string) {
+	conds := []string{"TRUE"}
+
+	if !filter.IncludeDeleted {
+		conds = append(conds, "deleted_at IS NULL")
+	}
+
+	if b := filter.BBox; b != nil {
+		env := fmt.Sprintf("ST_MakeEnvelope(%s, %s, %s, %s, 4326)::geography",
+			args.add(b.MinLon), args.add(b.MinLat), args.add(b.MaxLon), args.add(b.MaxLat))
+		conds = append(conds, `CASE
+				WHEN zone IS NULL THEN ST_DWithin(location, `+env+`, radius_meters)
+				ELSE ST_Intersects(zone, `+env+`)
+			END`)
+	}
+
+	var point string
+	if p := filter.Point; p != nil {
+		point = fmt.Sprintf("ST_MakePoint(%s, %s)::geography", args.add(p.Lon), args.add(p.Lat))
+	}
+
+	if filter.WithinMeters != nil && point != "" {
+		within := args.add(*filter.WithinMeters)
+		conds = append(conds, `CASE
+				WHEN zone IS NULL THEN ST_DWithin(location, `+point+`, radius_meters + `+within+`)
+				ELSE ST_DWithin(zone, `+point+`, `+within+`)
+			END`)
+	}
+
+	if len(filter.Types) > 0 {
+		conds = append(conds, "type = ANY("+args.add(pq.Array(filter.Types))+")")
+	}
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "status = ANY("+args.add(pq.Array(filter.Statuses))+")")
+	}
+	if filter.Active != nil {
+		conds = append(conds, "is_active = "+args.add(*filter.Active))
+	}
+	if filter.Live != nil {
+		conds = append(conds, "("+liveCondition+") = "+args.add(*filter.Live))
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+args.add(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "created_at < "+args.add(*filter.CreatedTo))
+	}
+	if filter.UpdatedFrom != nil {
+		conds = append(conds, "updated_at >= "+args.add(*filter.UpdatedFrom))
+	}
+	if filter.UpdatedTo != nil {
+		conds = append(conds, "updated_at < "+args.add(*filter.UpdatedTo))
+	}
+
+	switch filter.Sort {
+	case models.SortCreatedAsc:
+		orderBy = "created_at ASC, id ASC"
+	case models.SortUpdatedDesc:
+		orderBy = "updated_at DESC, id DESC"
+	case models.SortUpdatedAsc:
+		orderBy = "updated_at ASC, id ASC"
+	case models.SortDistance:
+		if point != "" {
+			orderBy = zoneDistanceSQL(point) + " ASC, id DESC"
+			break
+		}
+		fallthrough
+	default:
+		orderBy = "created_at DESC, id DESC"
+	}
+
+	return strings.Join(conds, "\n			AND "), orderBy
+}
+
+// cursorSQL дополняет условие where продолжением выборки после курсора
+// filter.After. Без курсора where возвращается как есть.
+func cursorSQL(where string, filter models.IncidentFilter, args *queryArgs) string {
+	c := filter.After
+	if c == nil {
+		return where
+	}
+	column, op := cursorColumn(filter.Sort)
+	return where + fmt.Sprintf("\n			AND (%s, id) %s (%s, %s)", column, op, args.add(c.Time), args.add(c.ID))
+}
+
+// limitArg превращает нулевой лимит в NULL, что для LIMIT означает «без ограничения».
+func limitArg(limit int) any {
+	if limit > 0 {
+		return limit
+	}
+	return nil
+}
+
+// cursorColumn возвращает колонку ключа сортировки и направление сравнения
+// для продолжения выборки после курсора.
+func cursorColumn(sort string) (column, op string) {
+	switch sort {
+	case models.SortCreatedAsc:
+		return "created_at", ">"
+	case models.SortUpdatedDesc:
+		return "updated_at", "<"
+	case models.SortUpdatedAsc:
+		return "updated_at", ">"
+	default:
+		return "created_at", "<"
+	}
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// incidentArea — зона инцидента как площадь: полигон, круг радиуса radius_meters
+// или линия с буфером buffer_meters.
+const incidentArea = `COALESCE(ST_Buffer(zone, buffer_meters), zone, ST_Buffer(location, radius_meters))`
+
+// FindDuplicateIncidents ищет включённые инциденты того же типа, созданные
+// не раньше window назад, зона которых перекрывает зону req не меньше чем
+// на minOverlap от площади меньшей из двух зон.
+func (r *IncidentRepo) FindDuplicateIncidents(
+	ctx context.Context,
+	req models.IncidentRequest,
+	window time.Duration,
+	minOverlap float64,
+) ([]models.DuplicateCandidate, error) {
+	query := `
+		WITH new_zone AS (
+			SELECT COALESCE(
+				ST_Buffer(ST_GeomFromGeoJSON($2::text)::geography, NULLIF($8::integer, 0)),
+				ST_GeomFromGeoJSON($2::text)::geography,
+				(SELECT ST_Union(geom::geometry)::geography FROM boundaries WHERE id = ANY($9::bigint[])),
+				ST_Buffer(ST_MakePoint($3, $4)::geography, NULLIF($5::integer, 0))
+			) AS area
+		)
+		SELECT id, type, severity, COALESCE(description, '') AS description, overlap, created_at
+		FROM (
+			SELECT
+				i.*,
+				ST_Area(ST_Intersection(` + incidentArea + `, n.area))
+					/ NULLIF(LEAST(ST_Area(` + incidentArea + `), ST_Area(n.area)), 0) AS overlap
+			FROM incidents i, new_zone n
+			WHERE i.type = $1
+				AND i.is_active
+				AND i.deleted_at IS NULL
+				AND i.created_at >= now() - $6::interval
+				AND ST_Intersects(` + incidentArea + `, n.area)
+		) candidates
+		WHERE overlap >= $7
+		ORDER BY overlap DESC, created_at DESC
+	`
+
+	candidates := []models.DuplicateCandidate{}
+	err := r.db.SelectContext(ctx, &candidates, query,
+		req.Type,
+		geometryArg(req.Geometry),
+		req.Longitude,
+		req.Latitude,
+		radiusArg(req),
+		fmt.Sprintf("%d seconds", int(window.Seconds())),
+		minOverlap,
+		bufferArg(req),
+		req.BoundaryIDs,
+	)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// MergeIncidents присоединяет дубль к основному инциденту: дубль удаляется
+// с пометкой merged_into, основной получает более высокую из двух
+// критичностей, а проверки локаций, совпавшие с дублем, — ссылку на основной.
+func (r *IncidentRepo) MergeIncidents(primaryID, duplicateID int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	// блокируем строки в порядке id, чтобы встречные слияния не взаимоблокировались
+	first, second := min(primaryID, duplicateID), max(primaryID, duplicateID)
+	locked := map[int]models.IncidentResponse{}
+	for _, id := range []int{first, second} {
+		inc, err := lockIncidentTx(tx, int64(id))
+		if err != nil {
+			return models.IncidentResponse{}, err
+		}
+		locked[id] = inc
+	}
+
+	primary, duplicate := locked[primaryID], locked[duplicateID]
+
+	if primary.DeletedAt != nil {
+		return models.IncidentResponse{}, sql.ErrNoRows
+	}
+	if duplicate.DeletedAt != nil || duplicate.Type != primary.Type {
+		return models.IncidentResponse{}, models.ErrMergeConflict
+	}
+	if !versionMatches(primary, meta) {
+		return models.IncidentResponse{}, models.ErrVersionConflict
+	}
+	// критичность дубля переносится в основной инцидент без согласования,
+	// а сам дубль удаляется
+	for _, inc := range []models.IncidentResponse{primary, duplicate} {
+		if err := models.CanEdit(inc.Status, meta.Role); err != nil {
+			return models.IncidentResponse{}, err
+		}
+	}
+
+	var mergedDuplicate models.IncidentResponse
+	err = tx.Get(&mergedDuplicate, `
+		UPDATE incidents
+		SET
+			is_active = false,
+			deleted_at = NOW(),
+			deleted_by = $2,
+			merged_into = $3,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING`+incidentColumns, duplicateID, meta.Operator, primaryID)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	severity := primary.Severity
+	if duplicate.Severity.Higher(severity) {
+		severity = duplicate.Severity
+	}
+
+	var mergedPrimary models.IncidentResponse
+	err = tx.Get(&mergedPrimary, `
+		UPDATE incidents
+		SET
+			severity = $2,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING`+incidentColumns, primaryID, severity)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO location_check_incidents (check_id, incident_id)
+		SELECT check_id, $1
+		FROM location_check_incidents
+		WHERE incident_id = $2
+		ON CONFLICT DO NOTHING`, primaryID, duplicateID)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := insertRevision(tx, int64(duplicateID), models.RevisionMerge, meta, &duplicate, &mergedDuplicate); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+	if err := insertRevision(tx, int64(primaryID), models.RevisionMerge, meta, &primary, &mergedPrimary); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return mergedPrimary, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// GetCancelledOccurrences возвращает начала отменённых повторений инцидента.
+func (r *IncidentRepo) GetCancelledOccurrences(ctx context.Context, id int) ([]time.Time, error) {
+	starts, err := cancelledOccurrences(ctx, r.db, int64(id))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return starts, nil
+}
+
+func cancelledOccurrences(ctx context.Context, q sqlx.QueryerContext, id int64) ([]time.Time, error) {
+	const query = `
+		SELECT occurrence_start
+		FROM incident_occurrence_cancellations
+		WHERE incident_id = $1
+		ORDER BY occurrence_start`
+
+	starts := []time.Time{}
+	err := sqlx.SelectContext(ctx, q, &starts, query, id)
+	return starts, err
+}
+
+// occurrenceWindow возвращает текущее или ближайшее неотменённое повторение
+// серии на момент now; nil — инцидент не повторяется или серия исчерпана.
+func occurrenceWindow(rec *models.Recurrence, startsAt, endsAt *time.Time, cancelled []time.Time, now time.Time) (start, end *time.Time) {
+	if rec == nil || startsAt == nil {
+		return nil, nil
+	}
+
+	o, ok := rec.Next(*startsAt, endsAt, now, cancelled)
+	if !ok {
+		return nil, nil
+	}
+
+	return &o.StartsAt, &o.EndsAt
+}
+
+// CancelOccurrence отменяет одно повторение и пишет ревизию; повторная отмена
+// ничего не меняет. Если отменено ещё не начавшееся текущее повторение, окно
+// сразу переходит к следующему; идущее повторение обрывается сейчас, а дальше
+// серию продвигает AdvanceOccurrences, отправив событие об окончании.
+func (r *IncidentRepo) CancelOccurrence(ctx context.Context, id int, start time.Time, meta models.ChangeMeta) error {
+	const insertQuery = `
+		INSERT INTO incident_occurrence_cancellations (incident_id, occurrence_start, cancelled_by, reason)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+		ON CONFLICT (incident_id, occurrence_start) DO NOTHING`
+
+	updateQuery := `
+		UPDATE incidents
+		SET
+			occurrence_starts_at = $2,
+			occurrence_ends_at = $3,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING` + incidentColumns
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := lockIncidentTx(tx, int64(id))
+	if err != nil {
+		return err
+	}
+
+	// отмена идущего повторения снимает оповещения без согласования
+	if err := models.CanEdit(before.Status, meta.Role); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, insertQuery, id, start, meta.Operator, meta.Reason)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
+	}
+
+	var now time.Time
+	if err := tx.GetContext(ctx, &now, `SELECT now()`); err != nil {
+		return err
+	}
+
+	occurrenceStart, occurrenceEnd := before.OccurrenceStartsAt, before.OccurrenceEndsAt
+	if occurrenceStart != nil && occurrenceStart.Equal(start) {
+		if before.Live {
+			occurrenceEnd = &now
+		} else {
+			cancelled, err := cancelledOccurrences(ctx, tx, int64(id))
+			if err != nil {
+				return err
+			}
+			occurrenceStart, occurrenceEnd = occurrenceWindow(before.Recurrence, before.StartsAt, before.EndsAt, cancelled, now)
+		}
+	}
+
+	var after models.IncidentResponse
+	if err := tx.GetContext(ctx, &after, updateQuery, id, occurrenceStart, occurrenceEnd); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if err := insertRevision(tx, int64(id), models.RevisionCancel, meta, &before, &after); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// dueSeries — серия, текущее повторение которой закончилось.
+type dueSeries struct {
+	models.IncidentEvent
+	Recurrence     models.Recurrence `db:"recurrence"`
+	SeriesStartsAt *time.Time        `db:"series_starts_at"`
+	SeriesEndsAt   *time.Time        `db:"series_ends_at"`
+	OccurrenceEnd  *time.Time        `db:"occurrence_ends_at"`
+	ScheduleState  string            `db:"schedule_state"`
+}
+
+// AdvanceOccurrences переводит повторяющиеся инциденты, текущее повторение
+// которых закончилось, к следующему неотменённому повторению и возвращает
+// закончившиеся повторения, о начале которых было отправлено событие.
+// Исчерпанная серия выключается, как инцидент с истёкшим окном действия.
+func (r *IncidentRepo) AdvanceOccurrences(ctx context.Context) ([]models.IncidentEvent, error) {
+	selectQuery := `
+		SELECT` + incidentEventColumns + `,
+			recurrence,
+			starts_at AS series_starts_at,
+			ends_at AS series_ends_at,
+			occurrence_ends_at,
+			schedule_state
+		FROM incidents
+		WHERE recurrence IS NOT NULL
+			AND schedule_state <> 'ended'
+			AND (occurrence_ends_at IS NULL OR occurrence_ends_at <= now())
+		FOR UPDATE SKIP LOCKED`
+
+	const nextQuery = `
+		UPDATE incidents
+		SET
+			occurrence_starts_at = $2,
+			occurrence_ends_at = $3,
+			schedule_state = 'pending'
+		WHERE id = $1`
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var due []dueSeries
+	if err := tx.SelectContext(ctx, &due, selectQuery); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	var now time.Time
+	if err := tx.GetContext(ctx, &now, `SELECT now()`); err != nil {
+		return nil, err
+	}
+
+	var ended []models.IncidentEvent
+	for _, s := range due {
+		cancelled, err := cancelledOccurrences(ctx, tx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		start, end := occurrenceWindow(&s.Recurrence, s.SeriesStartsAt, s.SeriesEndsAt, cancelled, now)
+		if start != nil {
+			_, err = tx.ExecContext(ctx, nextQuery, s.ID, start, end)
+		} else {
+			_, err = expireIncidentTx(ctx, tx, s.ID)
+		}
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		// окно пустое только до первого прохода планировщика
+		if s.ScheduleState == "started" && s.OccurrenceEnd != nil {
+			ended = append(ended, s.IncidentEvent)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ended, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrenceWindow(t *testing.T) {
+	daily := &models.Recurrence{RRule: "FREQ=DAILY;COUNT=3", DurationSeconds: 3600}
+	dtstart := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 2, 10, 30, 0, 0, time.UTC)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	testTable := []struct {
+		name          string
+		rec           *models.Recurrence
+		endsAt        *time.Time
+		cancelled     []time.Time
+		now           time.Time
+		expectedStart *time.Time
+		expectedEnd   *time.Time
+	}{
+		{
+			name:          "Current occurrence",
+			rec:           daily,
+			now:           at(1, 10, 30),
+			expectedStart: ptr(at(1, 10, 0)),
+			expectedEnd:   ptr(at(1, 11, 0)),
+		},
+		{
+			name:          "Between occurrences",
+			rec:           daily,
+			now:           at(1, 12, 0),
+			expectedStart: ptr(at(2, 10, 0)),
+			expectedEnd:   ptr(at(2, 11, 0)),
+		},
+		{
+			name:          "Cancelled skipped",
+			rec:           daily,
+			cancelled:     []time.Time{at(2, 10, 0)},
+			now:           at(1, 12, 0),
+			expectedStart: ptr(at(3, 10, 0)),
+			expectedEnd:   ptr(at(3, 11, 0)),
+		},
+		{
+			name:          "Cut by series end",
+			rec:           daily,
+			endsAt:        &until,
+			now:           at(2, 10, 15),
+			expectedStart: ptr(at(2, 10, 0)),
+			expectedEnd:   &until,
+		},
+		{
+			name:   "Series end passed",
+			rec:    daily,
+			endsAt: &until,
+			now:    at(2, 10, 45),
+		},
+		{
+			name: "Count exhausted",
+			rec:  daily,
+			now:  at(3, 11, 0),
+		},
+		{
+			name: "Not recurring",
+			now:  at(1, 10, 30),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			start, end := occurrenceWindow(testCase.rec, &dtstart, testCase.endsAt, testCase.cancelled, testCase.now)
+			assert.Equal(t, testCase.expectedStart, start)
+			assert.Equal(t, testCase.expectedEnd, end)
+		})
+	}
+}
+
+func TestCancelOccurrence_EndsCurrentOccurrence(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+	ctx := context.Background()
+
+	ensureTestIncidentType(t, db)
+
+	startsAt := time.Now().Add(-30 * time.Minute).Truncate(time.Second)
+	created, err := repo.CreateIncident(models.IncidentRequest{
+		Type:         testIncidentType,
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 500,
+		Active:       true,
+		StartsAt:     &startsAt,
+		Recurrence:   &models.Recurrence{RRule: "FREQ=DAILY", DurationSeconds: 3600},
+	}, models.ChangeMeta{Operator: "test"})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Exec(`DELETE FROM incidents WHERE id = $1`, created.ID) })
+
+	_, err = db.Exec(`UPDATE incidents SET status = 'published' WHERE id = $1`, created.ID)
+	require.NoError(t, err)
+
+	current, err := repo.GetIncidentById(int(created.ID))
+	require.NoError(t, err)
+	require.NotNil(t, current.OccurrenceStartsAt)
+	assert.True(t, current.OccurrenceStartsAt.Equal(startsAt))
+	assert.True(t, current.Live)
+
+	err = repo.CancelOccurrence(ctx, int(created.ID), startsAt, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit)
+
+	require.NoError(t, repo.CancelOccurrence(ctx, int(created.ID), startsAt, models.ChangeMeta{Operator: "root", Role: models.RoleAdmin}))
+
+	cancelled, err := repo.GetIncidentById(int(created.ID))
+	require.NoError(t, err)
+	assert.False(t, cancelled.Live)
+	assert.Equal(t, current.Version+1, cancelled.Version)
+
+	history, err := repo.GetIncidentHistory(int(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, models.RevisionCancel, history[len(history)-1].Action)
+
+	_, err = repo.AdvanceOccurrences(ctx)
+	require.NoError(t, err)
+
+	next, err := repo.GetIncidentById(int(created.ID))
+	require.NoError(t, err)
+	require.NotNil(t, next.OccurrenceStartsAt)
+	assert.True(t, next.OccurrenceStartsAt.Equal(startsAt.AddDate(0, 0, 1)))
+	assert.False(t, next.Live)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const revisionColumns = `
+			incident_id,
+			revision,
+			action,
+			operator,
+			COALESCE(reason, '') AS reason,
+			before,
+			after,
+			created_at`
+
+func (r *IncidentRepo) GetIncidentHistory(id int) ([]models.IncidentRevision, error) {
+	query := `
+		SELECT` + revisionColumns + `
+		FROM incident_revisions
+		WHERE incident_id = $1
+		ORDER BY revision
+	`
+
+	var revisions []models.IncidentRevision
+	if err := r.db.Select(&revisions, query, id); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *IncidentRepo) GetIncidentRevision(id, revision int) (models.IncidentRevision, error) {
+	query := `
+		SELECT` + revisionColumns + `
+		FROM incident_revisions
+		WHERE incident_id = $1 AND revision = $2
+	`
+
+	var rev models.IncidentRevision
+	if err := r.db.Get(&rev, query, id, revision); err != nil {
+		return models.IncidentRevision{}, err
+	}
+
+	return rev, nil
+}
+
+// lockIncidentTx читает текущее состояние инцидента и блокирует строку до конца
+// транзакции, чтобы снимок «до» и номер ревизии не разъехались с параллельными правками.
+func lockIncidentTx(tx *sqlx.Tx, id int64) (models.IncidentResponse, error) {
+	query := `
+		SELECT` + incidentColumns + `
+		FROM incidents
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var inc models.IncidentResponse
+	err := tx.Get(&inc, query, id)
+	return inc, err
+}
+
+// versionMatches проверяет предусловие If-Match по заблокированной строке.
+func versionMatches(inc models.IncidentResponse, meta models.ChangeMeta) bool {
+	return meta.IfVersion == 0 || inc.Version == meta.IfVersion
+}
+
+func insertRevision(
+	tx *sqlx.Tx,
+	incidentID int64,
+	action string,
+	meta models.ChangeMeta,
+	before, after *models.IncidentResponse,
+) error {
+	const query = `
+		INSERT INTO incident_revisions
+		(incident_id, revision, action, operator, reason, before, after)
+		SELECT
+			$1,
+			COALESCE(MAX(revision), 0) + 1,
+			$2,
+			$3,
+			NULLIF($4, ''),
+			$5::jsonb,
+			$6::jsonb
+		FROM incident_revisions
+		WHERE incident_id = $1
+	`
+
+	beforeJSON, err := snapshotArg(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshotArg(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(query, incidentID, action, meta.Operator, meta.Reason, beforeJSON, afterJSON)
+	return err
+}
+
+func snapshotArg(inc *models.IncidentResponse) (any, error) {
+	if inc == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(inc)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// searchHeadlineOptions — параметры ts_headline для фрагментов с подсветкой.
+const searchHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=" … "`
+
+// searchDescriptionHTML — описание с экранированными спецсимволами HTML:
+// фрагмент отдаётся клиенту как разметка, и разметкой в нём должен быть
+// только <mark>.
+const searchDescriptionHTML = `replace(replace(replace(replace(replace(COALESCE(description, ''),
+				'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
+// SearchIncidents ищет инциденты по типу и описанию. Запрос разбирается
+// websearch_to_tsquery в русской и английской конфигурациях, так что
+// поддерживаются кавычки, OR и исключение слов через минус. Фрагмент
+// подсвечивается в той конфигурации, в которой совпало описание.
+func (r *IncidentRepo) SearchIncidents(ctx context.Context, text string, filter models.IncidentFilter) (models.IncidentSearchResult, error) {
+	var args queryArgs
+	where, orderBy := incidentFilterSQL(filter, &args)
+	if filter.Sort == models.SortRelevance {
+		orderBy = "rank DESC, id DESC"
+	}
+
+	q := args.add(text)
+
+	query := `
+		SELECT` + incidentColumns + `,
+			ts_rank_cd(search_vector, s.tsq) AS rank,
+			CASE
+				WHEN to_tsvector('russian', COALESCE(description, '')) @@ s.ru
+					THEN ts_headline('russian', ` + searchDescriptionHTML + `, s.ru, '` + searchHeadlineOptions + `')
+				ELSE ts_headline('english', ` + searchDescriptionHTML + `, s.en, '` + searchHeadlineOptions + `')
+			END AS snippet
+		FROM incidents,
+			(SELECT ru, en, ru || en AS tsq
+			FROM websearch_to_tsquery('russian', ` + q + `) ru, websearch_to_tsquery('english', ` + q + `) en) s
+		WHERE ` + cursorSQL(where, filter, &args) + `
+			AND search_vector @@ s.tsq
+		ORDER BY ` + orderBy + `
+		LIMIT ` + args.add(filter.Limit+1) + ` OFFSET ` + args.add(filter.Offset)
+
+	hits := []models.IncidentSearchHit{}
+	if err := r.db.SelectContext(ctx, &hits, query, args...); err != nil {
+		log.Println(err)
+		return models.IncidentSearchResult{}, err
+	}
+
+	result := models.IncidentSearchResult{Items: hits}
+	if len(hits) > filter.Limit {
+		result.Items = hits[:filter.Limit]
+		result.HasMore = true
+	}
+
+	return result, nil
+}
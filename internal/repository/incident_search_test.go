@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIncidents_SnippetEscaped(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+	_, err := db.Exec(`UPDATE incidents SET description = $2 WHERE id = $1`,
+		id, `<img src=x onerror="alert(1)"> пожар на складе`)
+	require.NoError(t, err)
+
+	result, err := repo.SearchIncidents(context.Background(), "пожар",
+		models.IncidentFilter{Types: []string{testIncidentType}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+
+	snippet := result.Items[0].Snippet
+	assert.Contains(t, snippet, "<mark>пожар</mark>")
+	assert.Contains(t, snippet, "&lt;img")
+	assert.NotContains(t, snippet, "<img")
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type IncidentTemplateRepo struct {
+	db *sqlx.DB
+}
+
+func NewIncidentTemplatePostgres(db *sqlx.DB) *IncidentTemplateRepo {
+	return &IncidentTemplateRepo{db: db}
+}
+
+const incidentTemplateColumns = `
+			id,
+			name,
+			type,
+			COALESCE(severity, '') AS severity,
+			description,
+			COALESCE(radius_meters, 0) AS radius_meters,
+			tiers,
+			created_at,
+			updated_at`
+
+func (r *IncidentTemplateRepo) ListIncidentTemplates(ctx context.Context) ([]models.IncidentTemplate, error) {
+	query := `
+		SELECT` + incidentTemplateColumns + `
+		FROM incident_templates
+		ORDER BY name
+	`
+
+	templates := []models.IncidentTemplate{}
+	if err := r.db.SelectContext(ctx, &templates, query); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *IncidentTemplateRepo) GetIncidentTemplate(ctx context.Context, id int64) (models.IncidentTemplate, error) {
+	query := `
+		SELECT` + incidentTemplateColumns + `
+		FROM incident_templates
+		WHERE id = $1
+	`
+
+	var t models.IncidentTemplate
+	err := r.db.GetContext(ctx, &t, query, id)
+	return t, err
+}
+
+func (r *IncidentTemplateRepo) CreateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	query := `
+		INSERT INTO incident_templates (name, type, severity, description, radius_meters, tiers)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5::integer, 0), $6)
+		RETURNING` + incidentTemplateColumns
+
+	var created models.IncidentTemplate
+	err := r.db.GetContext(ctx, &created, query,
+		t.Name, t.Type, t.Severity, t.Description, t.RadiusMeters, t.Tiers)
+
+	return created, templateError(err)
+}
+
+func (r *IncidentTemplateRepo) UpdateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	query := `
+		UPDATE incident_templates
+		SET
+			name = $2,
+			type = $3,
+			severity = NULLIF($4, ''),
+			description = $5,
+			radius_meters = NULLIF($6::integer, 0),
+			tiers = $7,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING` + incidentTemplateColumns
+
+	var updated models.IncidentTemplate
+	err := r.db.GetContext(ctx, &updated, query,
+		t.ID, t.Name, t.Type, t.Severity, t.Description, t.RadiusMeters, t.Tiers)
+
+	return updated, templateError(err)
+}
+
+func (r *IncidentTemplateRepo) DeleteIncidentTemplate(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM incident_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// templateError переводит нарушения ограничений в ошибки шаблонов: занятое
+// имя и неизвестный тип инцидента.
+func templateError(err error) error {
+	switch pgErrorCode(err) {
+	case pgUniqueViolation:
+		return models.ErrIncidentTemplateExists
+	case pgForeignKeyViolation:
+		return models.ErrUnknownIncidentType
+	}
+	return err
+}
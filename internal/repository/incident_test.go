@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllIncidents_TotalWithCursor(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	for i := 0; i < 3; i++ {
+		insertTestIncident(t, db, models.StatusPublished)
+	}
+
+	filter := models.IncidentFilter{Types: []string{testIncidentType}, Limit: 2, WithTotal: true}
+	first, err := repo.GetAllIncidents(filter)
+	require.NoError(t, err)
+	require.NotNil(t, first.Total)
+	assert.EqualValues(t, 3, *first.Total)
+	require.True(t, first.HasMore)
+
+	cursor, err := models.DecodeCursor(first.NextCursor)
+	require.NoError(t, err)
+	filter.After = &cursor
+
+	second, err := repo.GetAllIncidents(filter)
+	require.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	require.NotNil(t, second.Total)
+	assert.EqualValues(t, 3, *second.Total)
+}
+
+func TestCreateIncident_WithoutWindowStartsOnPublish(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+	ctx := context.Background()
+
+	ensureTestIncidentType(t, db)
+
+	created, err := repo.CreateIncident(models.IncidentRequest{
+		Type:         testIncidentType,
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 500,
+		Active:       true,
+	}, models.ChangeMeta{Operator: "test"})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Exec(`DELETE FROM incidents WHERE id = $1`, created.ID) })
+	assert.Equal(t, models.StatusDraft, created.Status)
+
+	started := func() bool {
+		events, err := repo.StartScheduledIncidents(ctx)
+		require.NoError(t, err)
+		for _, e := range events {
+			if e.ID == created.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.False(t, started(), "draft must not emit incident_started")
+
+	_, err = db.Exec(`UPDATE incidents SET status = 'published' WHERE id = $1`, created.ID)
+	require.NoError(t, err)
+
+	assert.True(t, started())
+	assert.False(t, started(), "incident_started is emitted once")
+}
+
+func TestEndExpiredIncidents_WritesRevision(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+	_, err := db.Exec(`
+		UPDATE incidents
+		SET starts_at = now() - interval '2 hours', ends_at = now() - interval '1 hour', schedule_state = 'started'
+		WHERE id = $1
+	`, id)
+	require.NoError(t, err)
+
+	before, err := repo.GetIncidentById(int(id))
+	require.NoError(t, err)
+
+	events, err := repo.EndExpiredIncidents(context.Background())
+	require.NoError(t, err)
+
+	var ended bool
+	for _, e := range events {
+		ended = ended || e.ID == id
+	}
+	assert.True(t, ended)
+
+	after, err := repo.GetIncidentById(int(id))
+	require.NoError(t, err)
+	assert.False(t, after.Active)
+	assert.Equal(t, before.Version+1, after.Version)
+
+	history, err := repo.GetIncidentHistory(int(id))
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	last := history[len(history)-1]
+	assert.Equal(t, models.RevisionExpire, last.Action)
+	assert.Equal(t, schedulerOperator, last.Operator)
+}
+
+func TestImportRowError(t *testing.T) {
+	req := models.IncidentRequest{Type: "smoke"}
+
+	testTable := []struct {
+		name        string
+		err         error
+		expectedMsg string
+	}{
+		{
+			name: "Unknown type",
+			err: &pq.Error{
+				Code:       pgForeignKeyViolation,
+				Message:    `insert or update on table "incidents" violates foreign key constraint "incidents_type_fkey"`,
+				Constraint: "incidents_type_fkey",
+			},
+			expectedMsg: `validation error: unknown incident type "smoke"`,
+		},
+		{
+			name:        "Check constraint",
+			err:         &pq.Error{Code: pgCheckViolation, Message: `new row for relation "incidents" violates check constraint "incidents_window_check"`},
+			expectedMsg: "validation error: invalid incident data",
+		},
+		{
+			name:        "Out of range value",
+			err:         &pq.Error{Code: "22003", Message: "integer out of range"},
+			expectedMsg: "validation error: invalid incident data",
+		},
+		{
+			name:        "Invalid geometry",
+			err:         &pq.Error{Code: pgInternalError, Message: "lwgeom_intersection: GEOS Error: TopologyException"},
+			expectedMsg: "validation error: invalid incident data",
+		},
+		{
+			name: "Connection failure",
+			err:  errors.New("driver: bad connection"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := importRowError(req, testCase.err)
+
+			if testCase.expectedMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, models.ErrValidation)
+			assert.EqualError(t, err, testCase.expectedMsg)
+		})
+	}
+}
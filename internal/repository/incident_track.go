@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// AddTrackPoints добавляет точки в трек инцидента. Точка с уже известным
+// recorded_at заменяет прежнюю, так что повторная отправка безопасна.
+// Трек — телеметрия, а не версионируемое состояние: он не входит в снимок
+// инцидента, поэтому версия не растёт и ревизия не пишется. Но трек двигает
+// зону оповещения, поэтому опубликованный инцидент ведёт только администратор.
+func (r *IncidentRepo) AddTrackPoints(ctx context.Context, id int, points []models.TrackPoint, meta models.ChangeMeta) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var incident struct {
+		HasZone bool                  `db:"has_zone"`
+		Status  models.IncidentStatus `db:"status"`
+	}
+	err = tx.GetContext(ctx, &incident, `
+		SELECT zone IS NOT NULL AS has_zone, status
+		FROM incidents
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, id)
+	if err != nil {
+		return err
+	}
+	if incident.HasZone {
+		return models.ErrTrackUnsupported
+	}
+	if err := models.CanEdit(incident.Status, meta.Role); err != nil {
+		return err
+	}
+
+	const insert = `
+		INSERT INTO incident_track_points (incident_id, recorded_at, location)
+		VALUES ($1, $2, ST_MakePoint($3, $4)::geography)
+		ON CONFLICT (incident_id, recorded_at) DO UPDATE SET location = EXCLUDED.location`
+
+	for _, p := range points {
+		if _, err := tx.ExecContext(ctx, insert, id, p.RecordedAt, p.Longitude, p.Latitude); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE incidents SET tracked = TRUE WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *IncidentRepo) GetIncidentTrack(ctx context.Context, id int) ([]models.TrackPoint, error) {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT TRUE FROM incidents WHERE id = $1 AND deleted_at IS NULL`, id); err != nil {
+		return nil, err
+	}
+
+	const query = `
+		SELECT
+			ST_Y(location::geometry) AS latitude,
+			ST_X(location::geometry) AS longitude,
+			recorded_at
+		FROM incident_track_points
+		WHERE incident_id = $1
+		ORDER BY recorded_at`
+
+	points := []models.TrackPoint{}
+	if err := r.db.SelectContext(ctx, &points, query, id); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return points, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTrackPoints_KeepsVersion(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+	before, err := repo.GetIncidentById(int(id))
+	require.NoError(t, err)
+
+	err = repo.AddTrackPoints(context.Background(), int(id), []models.TrackPoint{
+		{Latitude: 55.76, Longitude: 37.62, RecordedAt: time.Now().Truncate(time.Second)},
+	}, models.ChangeMeta{Operator: "root", Role: models.RoleAdmin})
+	require.NoError(t, err)
+
+	after, err := repo.GetIncidentById(int(id))
+	require.NoError(t, err)
+	assert.Equal(t, before.Version, after.Version)
+
+	history, err := repo.GetIncidentHistory(int(id))
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestAddTrackPoints_PublishedRequiresAdmin(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+	points := []models.TrackPoint{{Latitude: 55.76, Longitude: 37.62, RecordedAt: time.Now().Truncate(time.Second)}}
+
+	err := repo.AddTrackPoints(context.Background(), int(id), points, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit)
+
+	draft := insertTestIncident(t, db, models.StatusDraft)
+	err = repo.AddTrackPoints(context.Background(), int(draft), points, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.NoError(t, err)
+}
+
+func TestGetIncidentTrack_DeletedIncident(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+	ctx := context.Background()
+
+	id := insertTestIncident(t, db, models.StatusDraft)
+	err := repo.AddTrackPoints(ctx, int(id), []models.TrackPoint{
+		{Latitude: 55.76, Longitude: 37.62, RecordedAt: time.Now().Truncate(time.Second)},
+	}, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteIncident(int(id), models.ChangeMeta{Operator: "alice", Role: models.RoleOperator}))
+
+	_, err = repo.GetIncidentTrack(ctx, int(id))
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// TransitionIncident переводит инцидент в состояние req.To, если переход
+// разрешён роли meta.Role, и сохраняет его вместе с комментарием.
+func (r *IncidentRepo) TransitionIncident(
+	ctx context.Context,
+	id int,
+	req models.TransitionRequest,
+	meta models.ChangeMeta,
+) (models.IncidentResponse, error) {
+	query := `
+		UPDATE incidents
+		SET
+			status = $2,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING` + incidentColumns
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockIncidentTx(tx, int64(id))
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	if before.DeletedAt != nil {
+		return models.IncidentResponse{}, sql.ErrNoRows
+	}
+
+	if !versionMatches(before, meta) {
+		return models.IncidentResponse{}, models.ErrVersionConflict
+	}
+
+	if err := models.CanTransition(before.Status, req.To, meta.Role); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	var after models.IncidentResponse
+	if err := tx.GetContext(ctx, &after, query, id, req.To); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO incident_transitions (incident_id, from_status, to_status, operator, role, comment)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+	`, id, before.Status, req.To, meta.Operator, meta.Role, req.Comment)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := insertRevision(tx, int64(id), models.RevisionTransition, meta, &before, &after); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	return after, nil
+}
+
+// GetIncidentTransitions возвращает переходы инцидента в порядке их выполнения.
+func (r *IncidentRepo) GetIncidentTransitions(ctx context.Context, id int) ([]models.IncidentTransition, error) {
+	const query = `
+		SELECT
+			id,
+			incident_id,
+			from_status,
+			to_status,
+			operator,
+			role,
+			COALESCE(comment, '') AS comment,
+			created_at
+		FROM incident_transitions
+		WHERE incident_id = $1
+		ORDER BY id`
+
+	transitions := []models.IncidentTransition{}
+	if err := r.db.SelectContext(ctx, &transitions, query, id); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type IncidentTypeRepo struct {
+	db *sqlx.DB
+}
+
+func NewIncidentTypePostgres(db *sqlx.DB) *IncidentTypeRepo {
+	return &IncidentTypeRepo{db: db}
+}
+
+const incidentTypeColumns = `
+			code,
+			names,
+			COALESCE(default_radius_meters, 0) AS default_radius_meters,
+			default_severity,
+			COALESCE(icon, '') AS icon,
+			created_at,
+			updated_at`
+
+// Коды ошибок PostgreSQL, которые переводятся в ошибки моделей.
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+	pgInternalError       = "XX000"
+	pgDataException       = "22" // класс ошибок данных
+)
+
+func (r *IncidentTypeRepo) ListIncidentTypes(ctx context.Context) ([]models.IncidentType, error) {
+	query := `
+		SELECT` + incidentTypeColumns + `
+		FROM incident_types
+		ORDER BY code
+	`
+
+	types := []models.IncidentType{}
+	if err := r.db.SelectContext(ctx, &types, query); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return types, nil
+}
+
+func (r *IncidentTypeRepo) GetIncidentType(ctx context.Context, code string) (models.IncidentType, error) {
+	query := `
+		SELECT` + incidentTypeColumns + `
+		FROM incident_types
+		WHERE code = $1
+	`
+
+	var t models.IncidentType
+	err := r.db.GetContext(ctx, &t, query, code)
+	return t, err
+}
+
+func (r *IncidentTypeRepo) CreateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	query := `
+		INSERT INTO incident_types (code, names, default_radius_meters, default_severity, icon)
+		VALUES ($1, $2, NULLIF($3::integer, 0), $4, NULLIF($5, ''))
+		RETURNING` + incidentTypeColumns
+
+	var created models.IncidentType
+	err := r.db.GetContext(ctx, &created, query,
+		t.Code, t.Names, t.DefaultRadiusMeters, t.DefaultSeverity.OrDefault(), t.Icon)
+	if pgErrorCode(err) == pgUniqueViolation {
+		return models.IncidentType{}, models.ErrIncidentTypeExists
+	}
+
+	return created, err
+}
+
+func (r *IncidentTypeRepo) UpdateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	query := `
+		UPDATE incident_types
+		SET
+			names = $2,
+			default_radius_meters = NULLIF($3::integer, 0),
+			default_severity = $4,
+			icon = NULLIF($5, ''),
+			updated_at = NOW()
+		WHERE code = $1
+		RETURNING` + incidentTypeColumns
+
+	var updated models.IncidentType
+	err := r.db.GetContext(ctx, &updated, query,
+		t.Code, t.Names, t.DefaultRadiusMeters, t.DefaultSeverity.OrDefault(), t.Icon)
+
+	return updated, err
+}
+
+// DeleteIncidentType удаляет тип; пока на него ссылаются инциденты (в том
+// числе удалённые), возвращает models.ErrIncidentTypeInUse.
+func (r *IncidentTypeRepo) DeleteIncidentType(ctx context.Context, code string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM incident_types WHERE code = $1`, code)
+	if pgErrorCode(err) == pgForeignKeyViolation {
+		return models.ErrIncidentTypeInUse
+	}
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetIncidentTypeStats считает инциденты по типам справочника, включая
+// типы без инцидентов. Удалённые инциденты не учитываются.
+func (r *IncidentTypeRepo) GetIncidentTypeStats(ctx context.Context) ([]models.IncidentTypeStat, error) {
+	query := `
+		SELECT
+			t.code,
+			t.names,
+			COUNT(i.id) FILTER (WHERE ` + liveCondition + `) AS live,
+			COUNT(i.id) AS total
+		FROM incident_types t
+		LEFT JOIN incidents i ON i.type = t.code AND i.deleted_at IS NULL
+		GROUP BY t.code, t.names
+		ORDER BY t.code
+	`
+
+	stats := []models.IncidentTypeStat{}
+	err := r.db.SelectContext(ctx, &stats, query)
+	return stats, err
+}
+
+func pgErrorCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
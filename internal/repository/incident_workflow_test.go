@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIncident_PublishedRequiresAdmin(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	req := models.IncidentRequest{
+		Type:         testIncidentType,
+		Latitude:     55.75,
+		Longitude:    37.61,
+		RadiusMeters: 5000,
+		Active:       true,
+	}
+
+	for _, status := range []models.IncidentStatus{models.StatusPublished, models.StatusResolved, models.StatusArchived} {
+		id := insertTestIncident(t, db, status)
+
+		_, err := repo.UpdateIncident(int(id), req, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+		assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit, status)
+
+		updated, err := repo.UpdateIncident(int(id), req, models.ChangeMeta{Operator: "root", Role: models.RoleAdmin})
+		require.NoError(t, err, status)
+		assert.Equal(t, 5000, updated.RadiusMeters)
+	}
+
+	draft := insertTestIncident(t, db, models.StatusDraft)
+	_, err := repo.UpdateIncident(int(draft), req, models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.NoError(t, err)
+}
+
+func TestMergeIncidents_PublishedRequiresAdmin(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	primary := insertTestIncident(t, db, models.StatusPublished)
+	duplicate := insertTestIncident(t, db, models.StatusDraft)
+
+	_, err := repo.MergeIncidents(int(primary), int(duplicate), models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit)
+
+	_, err = repo.MergeIncidents(int(primary), int(duplicate), models.ChangeMeta{Operator: "root", Role: models.RoleAdmin})
+	assert.NoError(t, err)
+}
+
+func TestMergeIncidents_PublishedDuplicateRequiresAdmin(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	primary := insertTestIncident(t, db, models.StatusDraft)
+	duplicate := insertTestIncident(t, db, models.StatusPublished)
+
+	_, err := repo.MergeIncidents(int(primary), int(duplicate), models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit)
+
+	_, err = repo.MergeIncidents(int(primary), int(duplicate), models.ChangeMeta{Operator: "root", Role: models.RoleAdmin})
+	assert.NoError(t, err)
+}
+
+func TestDeleteIncident_PublishedRequiresAdmin(t *testing.T) {
+	db := testDB(t)
+	repo := NewIncidentPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+
+	err := repo.DeleteIncident(int(id), models.ChangeMeta{Operator: "alice", Role: models.RoleOperator})
+	assert.ErrorIs(t, err, models.ErrReviewedIncidentEdit)
+
+	err = repo.DeleteIncident(int(id), models.ChangeMeta{Operator: "root", Role: models.RoleAdmin})
+	assert.NoError(t, err)
+}
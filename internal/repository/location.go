@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 	"log"
+	"time"
 )
 
 type LocationCheckRepo struct {
@@ -15,30 +18,136 @@ func NewLocationCheckPostgres(db *sqlx.DB) *LocationCheckRepo {
 	return &LocationCheckRepo{db: db}
 }
 
-func (r *LocationCheckRepo) CheckLocation(checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error) {
-	const query = `
-       WITH user_point AS (
+// incidentTiers — кольца зоны инцидента; без явных колец вся зона — одно кольцо
+// danger. Радиус круга с расписанием берётся на момент проверки.
+const incidentTiers = `COALESCE(
+					i.tiers,
+					jsonb_build_array(jsonb_build_object(
+						'level', 'danger',
+						'radius_meters', COALESCE(
+							incident_radius(i.radius_schedule, COALESCE(i.starts_at, i.created_at), now()),
+							i.radius_meters,
+							i.buffer_meters,
+							0
+						)
+					))
+				)`
+
+// incidentAlertArea — область, вне которой инцидент не оповещает. Выражение
+// совпадает с выражением индекса idx_incidents_alert_area.
+const incidentAlertArea = `incident_alert_area(
+					i.location,
+					i.zone,
+					incident_reach(i.radius_meters, i.buffer_meters, i.tiers)
+				)`
+
+// checkLocationQuery — $1, $2 — долгота и широта точки, $3 — горизонт
+// экстраполяции трека.
+const checkLocationQuery = `
+		WITH user_point AS (
 			SELECT ST_MakePoint($1, $2)::geography AS geom
 		)
 		SELECT
 			i.id,
 			i.type,
-			ST_Distance(i.location, up.geom) AS distance_meters
-		FROM incidents i, user_point up
+			i.severity,
+			d.distance_meters,
+			tier.level,
+			COALESCE(tier.next_level, ''),
+			d.tier_distance - tier.next_radius AS next_distance,
+			pocket.name IS NOT NULL AS in_pocket,
+			COALESCE(pocket.name, ''),
+			area.id,
+			COALESCE(area.code, ''),
+			COALESCE(area.name, ''),
+			COALESCE(area.level, '')
+		FROM incidents i
+		CROSS JOIN user_point up
+		CROSS JOIN LATERAL (
+			SELECT CASE
+				WHEN i.tracked THEN COALESCE(incident_position(i.id, now(), $3::interval), i.location)
+				ELSE i.location
+			END AS geom
+		) pos
+		CROSS JOIN LATERAL (
+			SELECT
+				CASE
+					WHEN i.zone IS NULL THEN ST_Distance(pos.geom, up.geom)
+					-- для линий — расстояние до линии
+					WHEN i.buffer_meters IS NOT NULL THEN ST_Distance(i.zone, up.geom)
+					-- для полигонов считаем расстояние до границы зоны
+					ELSE ST_Distance(ST_Boundary(i.zone::geometry)::geography, up.geom)
+				END AS distance_meters,
+				-- с радиусами колец сравнивается расстояние от центра круга,
+				-- от линии или от полигона (внутри полигона — 0)
+				CASE
+					WHEN i.zone IS NULL THEN ST_Distance(pos.geom, up.geom)
+					ELSE ST_Distance(i.zone, up.geom)
+				END AS tier_distance
+		) d
+		CROSS JOIN LATERAL (
+			SELECT rings.level, rings.next_level, rings.next_radius
+			FROM (
+				SELECT
+					t.level,
+					t.radius_meters,
+					LAG(t.level) OVER (ORDER BY t.radius_meters) AS next_level,
+					LAG(t.radius_meters) OVER (ORDER BY t.radius_meters) AS next_radius
+				FROM jsonb_to_recordset(` + incidentTiers + `) AS t(level TEXT, radius_meters DOUBLE PRECISION)
+			) rings
+			WHERE d.tier_distance <= rings.radius_meters
+			ORDER BY rings.radius_meters
+			LIMIT 1
+		) tier
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(e->>'name', '') AS name
+			FROM jsonb_array_elements(i.exclusions) e
+			WHERE CASE
+				WHEN jsonb_typeof(e->'geometry') = 'object'
+					THEN ST_Intersects(ST_GeomFromGeoJSON(e->>'geometry')::geography, up.geom)
+				ELSE ST_DWithin(
+					ST_MakePoint((e->>'longitude')::float8, (e->>'latitude')::float8)::geography,
+					up.geom,
+					(e->>'radius_meters')::float8
+				)
+			END
+			LIMIT 1
+		) pocket ON TRUE
+		-- для зоны из административных границ — самая мелкая граница
+		-- (вплоть до района), в которой находится точка
+		LEFT JOIN LATERAL (
+			SELECT b.id, b.code, b.name, b.level
+			FROM incident_boundaries ib
+			JOIN boundaries b ON b.path @> ARRAY[ib.boundary_id]
+			WHERE ib.incident_id = i.id
+				AND ST_Intersects(b.geom, up.geom)
+			ORDER BY cardinality(b.path) DESC
+			LIMIT 1
+		) area ON TRUE
 		WHERE
-			i.is_active = TRUE
-			AND ST_DWithin(
-				i.location,
-				up.geom,
-				i.radius_meters
+			` + liveCondition + `
+			-- отбор кандидатов по индексу до расчёта колец и исключений;
+			-- позиция движущегося инцидента известна только на момент проверки
+			AND (
+				i.tracked
+				OR ST_Intersects(` + incidentAlertArea + `, up.geom)
 			)
-		ORDER BY distance_meters;
-    `
-
+		ORDER BY distance_meters
+	`
+
+// CheckLocation находит действующие инциденты, в зону которых попадает точка,
+// и для каждого — самое внутреннее кольцо зоны, содержащее точку. Инциденты,
+// в безопасной области которых находится точка, возвращаются в SafePockets.
+// Для зон из административных границ указывается граница, в которой точка.
+// Повторяющиеся инциденты учитываются только во время своих повторений.
+// Движущиеся инциденты проверяются в их текущей позиции по треку; horizon
+// ограничивает экстраполяцию после последней точки трека.
+func (r *LocationCheckRepo) CheckLocation(checkReq models.LocationCheckRequest, horizon time.Duration) (models.LocationCheckResponse, error) {
 	rows, err := r.db.Query(
-		query,
+		checkLocationQuery,
 		checkReq.Lon,
 		checkReq.Lat,
+		fmt.Sprintf("%d seconds", int(horizon.Seconds())),
 	)
 
 	if err != nil {
@@ -50,17 +159,47 @@ func (r *LocationCheckRepo) CheckLocation(checkReq models.LocationCheckRequest)
 	resp := models.LocationCheckResponse{}
 
 	for rows.Next() {
-		var inc models.NearbyIncidentResponse
+		var (
+			inc       models.NearbyIncidentResponse
+			inPocket  bool
+			exclusion string
+			area      models.BoundaryRef
+			areaID    *int64
+		)
 
 		if err := rows.Scan(
 			&inc.ID,
 			&inc.Type,
+			&inc.Severity,
 			&inc.DistanceMeters,
+			&inc.Tier,
+			&inc.NextTier,
+			&inc.DistanceToNextTierMeters,
+			&inPocket,
+			&exclusion,
+			&areaID,
+			&area.Code,
+			&area.Name,
+			&area.Level,
 		); err != nil {
 			log.Println(err)
 			return models.LocationCheckResponse{}, err
 		}
 
+		if areaID != nil {
+			area.ID = *areaID
+			inc.Boundary = &area
+		}
+
+		if inPocket {
+			resp.SafePockets = append(resp.SafePockets, models.SafePocket{
+				IncidentID: inc.ID,
+				Type:       inc.Type,
+				Exclusion:  exclusion,
+			})
+			continue
+		}
+
 		resp.Incidents = append(resp.Incidents, inc)
 	}
 
@@ -69,15 +208,20 @@ func (r *LocationCheckRepo) CheckLocation(checkReq models.LocationCheckRequest)
 		return models.LocationCheckResponse{}, err
 	}
 
-	resp.Danger = len(resp.Incidents) > 0
-
 	return resp, nil
 }
 
-func (r *LocationCheckRepo) SaveCheck(userID int, lat, lon float64, hasDanger bool) error {
+// SaveCheck сохраняет проверку и инциденты, попавшие в её ответ.
+func (r *LocationCheckRepo) SaveCheck(userID int, lat, lon float64, hasDanger bool, incidentIDs []int64) error {
 	const query = `
-        INSERT INTO location_checks (user_id, location, has_danger)
-        VALUES ($1, ST_MakePoint($2, $3)::geography, $4)
+        WITH check_row AS (
+            INSERT INTO location_checks (user_id, location, has_danger)
+            VALUES ($1, ST_MakePoint($2, $3)::geography, $4)
+            RETURNING id
+        )
+        INSERT INTO location_check_incidents (check_id, incident_id)
+        SELECT check_row.id, incident_id
+        FROM check_row, unnest($5::bigint[]) AS incident_id
     `
 
 	_, err := r.db.ExecContext(
@@ -87,6 +231,7 @@ func (r *LocationCheckRepo) SaveCheck(userID int, lat, lon float64, hasDanger bo
 		lon,
 		lat,
 		hasDanger,
+		pq.Array(incidentIDs),
 	)
 
 	return err
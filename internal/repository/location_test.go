@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLocation_UsesAlertAreaIndex(t *testing.T) {
+	db := testDB(t)
+
+	tx, err := db.Beginx()
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// на пустой таблице планировщик и так выбрал бы полный просмотр
+	_, err = tx.Exec(`SET LOCAL enable_seqscan = off`)
+	require.NoError(t, err)
+
+	var plan []string
+	err = tx.Select(&plan, `EXPLAIN `+checkLocationQuery, 37.61, 55.75, "600 seconds")
+	require.NoError(t, err)
+
+	assert.Contains(t, strings.Join(plan, "\n"), "idx_incidents_alert_area")
+}
+
+func TestCheckLocation_CircleEdge(t *testing.T) {
+	db := testDB(t)
+	repo := NewLocationCheckPostgres(db)
+
+	id := insertTestIncident(t, db, models.StatusPublished)
+
+	found := func(lat, lon float64) bool {
+		resp, err := repo.CheckLocation(models.LocationCheckRequest{Lat: lat, Lon: lon}, 10*time.Minute)
+		require.NoError(t, err)
+		for _, inc := range resp.Incidents {
+			if inc.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 998 м от центра: буфер-многоугольник без запаса эту точку бы не покрыл
+	assert.True(t, found(55.75+0.0089221, 37.61+0.0015613))
+	assert.False(t, found(55.75+0.0095, 37.61))
+}
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+const idempotencyKeyPrefix = "idempotency:"
+
+type IdempotencyStore struct {
+	client *redis.Client
+}
+
+func NewIdempotencyStore(client *redis.Client) *IdempotencyStore {
+	return &IdempotencyStore{client: client}
+}
+
+// BeginIdempotent занимает ключ на время выполнения запроса. Если ключ уже
+// занят, возвращает сохранённый ответ либо ErrIdempotencyInFlight, пока
+// первый запрос не завершился. nil без ошибки — запрос нужно выполнить.
+func (s *IdempotencyStore) BeginIdempotent(
+	ctx context.Context,
+	key, fingerprint string,
+	lockTTL time.Duration,
+) (*models.IdempotentResponse, error) {
+	pending, err := json.Marshal(models.IdempotentResponse{
+		State:       models.IdempotencyPending,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, pending, lockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	data, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		// ключ истёк между SETNX и GET — считаем запрос выполняющимся
+		return nil, models.ErrIdempotencyInFlight
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored models.IdempotentResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+	if stored.State != models.IdempotencyDone {
+		return nil, models.ErrIdempotencyInFlight
+	}
+
+	return &stored, nil
+}
+
+// CompleteIdempotent сохраняет ответ для повторов на ttl.
+func (s *IdempotencyStore) CompleteIdempotent(
+	ctx context.Context,
+	key string,
+	resp models.IdempotentResponse,
+	ttl time.Duration,
+) error {
+	resp.State = models.IdempotencyDone
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, data, ttl).Err()
+}
+
+// AbortIdempotent освобождает ключ, чтобы клиент мог повторить запрос.
+func (s *IdempotencyStore) AbortIdempotent(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyKeyPrefix+key).Err()
+}
@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+type NotificationThrottle struct {
+	client *redis.Client
+}
+
+func NewNotificationThrottle(client *redis.Client) *NotificationThrottle {
+	return &NotificationThrottle{client: client}
+}
+
+// Allow сообщает, можно ли снова оповестить пользователя об инциденте в кольце
+// tier. Первый вызов в пределах interval занимает ключ, остальные получают false.
+func (t *NotificationThrottle) Allow(
+	ctx context.Context,
+	userID int,
+	incidentID int64,
+	tier models.TierLevel,
+	interval time.Duration,
+) (bool, error) {
+	if interval <= 0 {
+		return true, nil
+	}
+
+	key := fmt.Sprintf("notifications:%d:%d:%s", userID, incidentID, tier)
+
+	return t.client.SetNX(ctx, key, 1, interval).Result()
+}
@@ -8,15 +8,32 @@ import (
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 )
 
+const webhookQueueKey = "webhooks:queue"
+
+// WebhookQueueKeys — очереди вебхуков в порядке убывания приоритета.
+// BRPOP по этому списку всегда забирает задачу из самой приоритетной непустой очереди.
+var WebhookQueueKeys = []string{
+	WebhookQueueKey(models.PriorityHigh),
+	WebhookQueueKey(models.PriorityNormal),
+	WebhookQueueKey(models.PriorityLow),
+}
+
+func WebhookQueueKey(priority string) string {
+	switch priority {
+	case models.PriorityHigh, models.PriorityLow:
+		return webhookQueueKey + ":" + priority
+	default:
+		return webhookQueueKey
+	}
+}
+
 type WebhookRedisQueue struct {
 	client *redis.Client
-	key    string
 }
 
 func NewWebhookQueue(client *redis.Client) *WebhookRedisQueue {
 	return &WebhookRedisQueue{
 		client: client,
-		key:    "webhooks:queue",
 	}
 }
 
@@ -29,5 +46,5 @@ func (q *WebhookRedisQueue) Enqueue(
 		return err
 	}
 
-	return q.client.LPush(ctx, q.key, data).Err()
+	return q.client.LPush(ctx, WebhookQueueKey(job.Priority), data).Err()
 }
@@ -4,22 +4,66 @@ import (
 	"context"
 	"github.com/jmoiron/sqlx"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
+	redisrepo "github.com/rusinadaria/geo-notification-system/internal/repository/redis"
 	"time"
 )
 
 type Incident interface {
-	CreateIncident(incident models.IncidentRequest) error
-	GetAllIncidents(limit, offset int) ([]models.IncidentResponse, error)
+	CreateIncident(incident models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error)
+	CreateIncidents(reqs []models.IncidentRequest, meta models.ChangeMeta, partial bool) ([]int64, []error, error)
+	GetAllIncidents(filter models.IncidentFilter) (models.IncidentPage, error)
+	SearchIncidents(ctx context.Context, text string, filter models.IncidentFilter) (models.IncidentSearchResult, error)
 	GetIncidentById(id int) (models.IncidentResponse, error)
-	UpdateIncident(id int, req models.IncidentRequest) (models.IncidentResponse, error)
-	DeleteIncident(id int) error
+	ForEachIncident(ctx context.Context, filter models.IncidentFilter, fn func(inc models.IncidentResponse) error) error
+	UpdateIncident(id int, req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error)
+	DeleteIncident(id int, meta models.ChangeMeta) error
+	RestoreIncident(id int, meta models.ChangeMeta) (models.IncidentResponse, error)
+	PurgeIncident(ctx context.Context, id int, retention time.Duration) error
+	FindDuplicateIncidents(ctx context.Context, req models.IncidentRequest, window time.Duration, minOverlap float64) ([]models.DuplicateCandidate, error)
+	MergeIncidents(primaryID, duplicateID int, meta models.ChangeMeta) (models.IncidentResponse, error)
+	GetIncidentHistory(id int) ([]models.IncidentRevision, error)
+	AddTrackPoints(ctx context.Context, id int, points []models.TrackPoint, meta models.ChangeMeta) error
+	GetIncidentTrack(ctx context.Context, id int) ([]models.TrackPoint, error)
+	GetCancelledOccurrences(ctx context.Context, id int) ([]time.Time, error)
+	CancelOccurrence(ctx context.Context, id int, start time.Time, meta models.ChangeMeta) error
+	GetIncidentRevision(id, revision int) (models.IncidentRevision, error)
+	TransitionIncident(ctx context.Context, id int, req models.TransitionRequest, meta models.ChangeMeta) (models.IncidentResponse, error)
+	GetIncidentTransitions(ctx context.Context, id int) ([]models.IncidentTransition, error)
 	GetDangerStats(ctx context.Context, window time.Duration) (int64, error)
 	GetActiveIncidents(ctx context.Context) ([]models.IncidentResponse, error)
+	StartScheduledIncidents(ctx context.Context) ([]models.IncidentEvent, error)
+	EndExpiredIncidents(ctx context.Context) ([]models.IncidentEvent, error)
+	AdvanceOccurrences(ctx context.Context) ([]models.IncidentEvent, error)
+}
+
+type IncidentType interface {
+	ListIncidentTypes(ctx context.Context) ([]models.IncidentType, error)
+	GetIncidentType(ctx context.Context, code string) (models.IncidentType, error)
+	CreateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error)
+	UpdateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error)
+	DeleteIncidentType(ctx context.Context, code string) error
+	GetIncidentTypeStats(ctx context.Context) ([]models.IncidentTypeStat, error)
+}
+
+type IncidentTemplate interface {
+	ListIncidentTemplates(ctx context.Context) ([]models.IncidentTemplate, error)
+	GetIncidentTemplate(ctx context.Context, id int64) (models.IncidentTemplate, error)
+	CreateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error)
+	UpdateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error)
+	DeleteIncidentTemplate(ctx context.Context, id int64) error
+}
+
+type Boundary interface {
+	ListBoundaries(ctx context.Context, filter models.BoundaryFilter) ([]models.Boundary, error)
+	GetBoundary(ctx context.Context, id int64) (models.Boundary, error)
+	ImportBoundaries(ctx context.Context, boundaries []models.BoundaryImport) ([]int64, error)
+	DeleteBoundary(ctx context.Context, id int64) error
+	MissingBoundaries(ctx context.Context, ids models.BoundaryIDs) ([]int64, error)
 }
 
 type LocationCheck interface {
-	CheckLocation(checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error)
-	SaveCheck(userID int, lat, lon float64, hasDanger bool) error
+	CheckLocation(checkReq models.LocationCheckRequest, horizon time.Duration) (models.LocationCheckResponse, error)
+	SaveCheck(userID int, lat, lon float64, hasDanger bool, incidentIDs []int64) error
 }
 
 type IncidentCache interface {
@@ -28,6 +72,16 @@ type IncidentCache interface {
 	InvalidateActive(ctx context.Context) error
 }
 
+type NotificationThrottle interface {
+	Allow(ctx context.Context, userID int, incidentID int64, tier models.TierLevel, interval time.Duration) (bool, error)
+}
+
+type IdempotencyStore interface {
+	BeginIdempotent(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*models.IdempotentResponse, error)
+	CompleteIdempotent(ctx context.Context, key string, resp models.IdempotentResponse, ttl time.Duration) error
+	AbortIdempotent(ctx context.Context, key string) error
+}
+
 type DB interface {
 	PingContext(ctx context.Context) error
 }
@@ -38,16 +92,28 @@ type Redis interface {
 
 type Repository struct {
 	Incident
+	IncidentType
+	IncidentTemplate
+	Boundary
 	LocationCheck
+	IncidentCache
+	NotificationThrottle
+	IdempotencyStore
 	DB
 	Redis
 }
 
-func NewRepository(db *sqlx.DB, redis RedisPinger) *Repository {
+func NewRepository(db *sqlx.DB, redis *redisrepo.RedisClient) *Repository {
 	return &Repository{
-		Incident:      NewIncidentPostgres(db),
-		LocationCheck: NewLocationCheckPostgres(db),
-		DB:            db,
-		Redis:         redis,
+		Incident:             NewIncidentPostgres(db),
+		IncidentType:         NewIncidentTypePostgres(db),
+		IncidentTemplate:     NewIncidentTemplatePostgres(db),
+		Boundary:             NewBoundaryPostgres(db),
+		LocationCheck:        NewLocationCheckPostgres(db),
+		IncidentCache:        redisrepo.NewIncidentCache(redis.Client()),
+		NotificationThrottle: redisrepo.NewNotificationThrottle(redis.Client()),
+		IdempotencyStore:     redisrepo.NewIdempotencyStore(redis.Client()),
+		DB:                   db,
+		Redis:                redis,
 	}
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// testDB подключается к базе TEST_DB_PATH с применёнными миграциями
+// (сервис test-db из docker-compose). Без неё тесты пропускаются.
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	path := os.Getenv("TEST_DB_PATH")
+	if path == "" {
+		t.Skip("TEST_DB_PATH is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", path)
+	if err != nil {
+		t.Fatalf("connect test db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// testIncidentType — тип, под которым тесты заводят свои инциденты.
+const testIncidentType = "test_repository"
+
+// insertTestIncident заводит круговой инцидент радиусом 1 км в состоянии
+// status и удаляет его по завершении теста.
+func insertTestIncident(t *testing.T, db *sqlx.DB, status models.IncidentStatus) int64 {
+	t.Helper()
+
+	ensureTestIncidentType(t, db)
+
+	var id int64
+	err := db.Get(&id, `
+		INSERT INTO incidents (type, location, radius_meters, is_active, status)
+		VALUES ($1, ST_MakePoint(37.61, 55.75)::geography, 1000, true, $2)
+		RETURNING id
+	`, testIncidentType, status)
+	if err != nil {
+		t.Fatalf("insert incident: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM incidents WHERE id = $1`, id)
+	})
+
+	return id
+}
+
+// ensureTestIncidentType заводит тип testIncidentType, если его ещё нет.
+func ensureTestIncidentType(t *testing.T, db *sqlx.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`INSERT INTO incident_types (code) VALUES ($1) ON CONFLICT DO NOTHING`, testIncidentType)
+	if err != nil {
+		t.Fatalf("insert incident type: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+)
+
+type boundaryService struct {
+	repo repository.Boundary
+}
+
+func NewBoundaryService(repo repository.Boundary) *boundaryService {
+	return &boundaryService{repo: repo}
+}
+
+func (s *boundaryService) ListBoundaries(ctx context.Context, filter models.BoundaryFilter) ([]models.Boundary, error) {
+	return s.repo.ListBoundaries(ctx, filter)
+}
+
+func (s *boundaryService) GetBoundary(ctx context.Context, id int64) (models.Boundary, error) {
+	return s.repo.GetBoundary(ctx, id)
+}
+
+// ImportBoundaries загружает границы из файла целиком: при любой ошибке не
+// сохраняется ни одна граница.
+func (s *boundaryService) ImportBoundaries(ctx context.Context, boundaries []models.BoundaryImport) (models.BoundaryImportResult, error) {
+	seen := make(map[string]bool, len(boundaries))
+	for _, b := range boundaries {
+		if err := b.Validate(); err != nil {
+			return models.BoundaryImportResult{}, err
+		}
+		if seen[b.Code] {
+			return models.BoundaryImportResult{}, fmt.Errorf("%w: boundary %s is specified more than once", models.ErrValidation, b.Code)
+		}
+		seen[b.Code] = true
+	}
+
+	ids, err := s.repo.ImportBoundaries(ctx, boundaries)
+	if err != nil {
+		log.Println(err)
+		return models.BoundaryImportResult{}, err
+	}
+
+	return models.BoundaryImportResult{Imported: len(ids), IDs: ids}, nil
+}
+
+func (s *boundaryService) DeleteBoundary(ctx context.Context, id int64) error {
+	return s.repo.DeleteBoundary(ctx, id)
+}
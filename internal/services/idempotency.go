@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+)
+
+// idempotencyLockTTL — сколько ключ считается занятым выполняющимся запросом.
+// Если экземпляр упал посреди запроса, ключ освободится сам.
+const idempotencyLockTTL = time.Minute
+
+type idempotencyService struct {
+	store repository.IdempotencyStore
+	ttl   time.Duration
+}
+
+func NewIdempotencyService(store repository.IdempotencyStore, ttl time.Duration) *idempotencyService {
+	return &idempotencyService{store: store, ttl: ttl}
+}
+
+func (s *idempotencyService) BeginIdempotent(ctx context.Context, key, fingerprint string) (*models.IdempotentResponse, error) {
+	return s.store.BeginIdempotent(ctx, key, fingerprint, idempotencyLockTTL)
+}
+
+func (s *idempotencyService) CompleteIdempotent(ctx context.Context, key string, resp models.IdempotentResponse) error {
+	return s.store.CompleteIdempotent(ctx, key, resp, s.ttl)
+}
+
+func (s *idempotencyService) AbortIdempotent(ctx context.Context, key string) error {
+	return s.store.AbortIdempotent(ctx, key)
+}
@@ -3,42 +3,211 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 	"github.com/rusinadaria/geo-notification-system/internal/repository"
 	"log"
+	"sort"
 	"time"
 )
 
 type IncidentService struct {
-	repo         repository.Incident
-	cache        repository.IncidentCache
-	windowMin    int
-	webhookQueue WebhookQueue
+	repo           repository.Incident
+	types          repository.IncidentType
+	boundaries     repository.Boundary
+	cache          repository.IncidentCache
+	windowMin      int
+	purgeRetention time.Duration
+	duplicates     DuplicateDetection
+	webhookQueue   WebhookQueue
 }
 
-func NewIncidentService(repo repository.Incident, windowMin int, webhookQueue WebhookQueue) *IncidentService {
-	return &IncidentService{repo: repo, windowMin: windowMin, webhookQueue: webhookQueue}
+// DuplicateDetection — параметры поиска дублей при создании инцидента.
+// Нулевое окно отключает проверку.
+type DuplicateDetection struct {
+	Window     time.Duration
+	MinOverlap float64
 }
 
+func NewIncidentService(
+	repo repository.Incident,
+	types repository.IncidentType,
+	boundaries repository.Boundary,
+	cache repository.IncidentCache,
+	windowMin int,
+	purgeRetention time.Duration,
+	duplicates DuplicateDetection,
+	webhookQueue WebhookQueue,
+) *IncidentService {
+	return &IncidentService{
+		repo:           repo,
+		types:          types,
+		boundaries:     boundaries,
+		cache:          cache,
+		windowMin:      windowMin,
+		purgeRetention: purgeRetention,
+		duplicates:     duplicates,
+		webhookQueue:   webhookQueue,
+	}
+}
+
+const activeIncidentsTTL = 30 * time.Second
+
 var ErrIncidentAlreadyExists = errors.New("incident already exists")
 
-func (s *IncidentService) CreateIncident(req models.IncidentRequest) error {
-	err := s.repo.CreateIncident(req)
+// DuplicateIncidentError возвращается, когда создаваемый инцидент похож на
+// уже действующие; оборачивает ErrIncidentAlreadyExists.
+type DuplicateIncidentError struct {
+	Candidates []models.DuplicateCandidate
+}
+
+func (e *DuplicateIncidentError) Error() string {
+	return fmt.Sprintf("%v: %d candidate(s)", ErrIncidentAlreadyExists, len(e.Candidates))
+}
+
+func (e *DuplicateIncidentError) Unwrap() error {
+	return ErrIncidentAlreadyExists
+}
+
+// CreateIncident создаёт инцидент. Без force сначала ищутся возможные дубли
+// и при их наличии возвращается *DuplicateIncidentError.
+func (s *IncidentService) CreateIncident(req models.IncidentRequest, meta models.ChangeMeta, force bool) (models.IncidentResponse, error) {
+	ctx := context.Background()
+
+	if err := s.resolveType(ctx, &req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if err := s.checkBoundaries(ctx, req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	if !force && s.duplicates.Window > 0 {
+		candidates, err := s.repo.FindDuplicateIncidents(ctx, req, s.duplicates.Window, s.duplicates.MinOverlap)
+		if err != nil {
+			return models.IncidentResponse{}, err
+		}
+		if len(candidates) > 0 {
+			return models.IncidentResponse{}, &DuplicateIncidentError{Candidates: candidates}
+		}
+	}
+
+	created, err := s.repo.CreateIncident(req, meta)
 	if err != nil {
-		return err
+		return models.IncidentResponse{}, err
 	}
 
-	return nil
+	s.invalidateActive(context.Background())
+
+	return created, nil
 }
 
-func (s *IncidentService) GetAllIncidents(limit, offset int) ([]models.IncidentResponse, error) {
-	incidents, err := s.repo.GetAllIncidents(limit, offset)
+// ImportIncidents проверяет прочитанные из файла инциденты теми же правилами,
+// что и одиночное создание, и сохраняет их. В режиме atomic при любой ошибке
+// не создаётся ничего, в режиме partial создаются все корректные записи.
+func (s *IncidentService) ImportIncidents(rows []models.ImportRow, mode string, meta models.ChangeMeta) (models.ImportResult, error) {
+	result := models.ImportResult{
+		Mode:       mode,
+		Total:      len(rows),
+		CreatedIDs: []int64{},
+		Errors:     []models.ImportError{},
+	}
+
+	var (
+		reqs  []models.IncidentRequest
+		lines []int
+	)
+
+	catalog, err := s.types.ListIncidentTypes(context.Background())
 	if err != nil {
-		return []models.IncidentResponse{}, err
+		log.Println(err)
+		return models.ImportResult{}, err
+	}
+	types := make(map[string]models.IncidentType, len(catalog))
+	for _, t := range catalog {
+		types[t.Code] = t
 	}
 
-	return incidents, nil
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = row.Request.Validate()
+		}
+		if err == nil {
+			t, ok := types[row.Request.Type]
+			if !ok {
+				err = unknownTypeError(row.Request.Type)
+			} else {
+				err = applyTypeDefaults(&row.Request, t)
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, models.ImportError{Line: row.Line, Error: err.Error()})
+			continue
+		}
+
+		reqs = append(reqs, row.Request)
+		lines = append(lines, row.Line)
+	}
+
+	partial := mode == models.ImportModePartial
+
+	if len(reqs) == 0 || (!partial && len(result.Errors) > 0) {
+		return result, nil
+	}
+
+	ids, rowErrs, err := s.repo.CreateIncidents(reqs, meta, partial)
+	if err != nil {
+		log.Println(err)
+		return models.ImportResult{}, err
+	}
+
+	for i, rowErr := range rowErrs {
+		if rowErr != nil {
+			result.Errors = append(result.Errors, models.ImportError{Line: lines[i], Error: rowErr.Error()})
+			continue
+		}
+		if ids != nil {
+			result.CreatedIDs = append(result.CreatedIDs, ids[i])
+		}
+	}
+
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+
+	if len(result.CreatedIDs) > 0 {
+		s.invalidateActive(context.Background())
+	}
+
+	return result, nil
+}
+
+func (s *IncidentService) GetAllIncidents(filter models.IncidentFilter) (models.IncidentPage, error) {
+	page, err := s.repo.GetAllIncidents(filter)
+	if err != nil {
+		return models.IncidentPage{}, err
+	}
+
+	return page, nil
+}
+
+func (s *IncidentService) ExportIncidents(
+	ctx context.Context,
+	filter models.IncidentFilter,
+	fn func(inc models.IncidentResponse) error,
+) error {
+	return s.repo.ForEachIncident(ctx, filter, fn)
+}
+
+func (s *IncidentService) SearchIncidents(ctx context.Context, text string, filter models.IncidentFilter) (models.IncidentSearchResult, error) {
+	result, err := s.repo.SearchIncidents(ctx, text, filter)
+	if err != nil {
+		return models.IncidentSearchResult{}, err
+	}
+
+	return result, nil
 }
 
 func (s *IncidentService) GetIncidentById(id int) (models.IncidentResponse, error) {
@@ -50,7 +219,14 @@ func (s *IncidentService) GetIncidentById(id int) (models.IncidentResponse, erro
 	return incident, nil
 }
 
-func (s *IncidentService) UpdateIncident(id int, req models.IncidentRequest) (models.IncidentResponse, error) {
+func (s *IncidentService) UpdateIncident(id int, req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	if err := s.resolveType(context.Background(), &req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if err := s.checkBoundaries(context.Background(), req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
 	_, err := s.repo.GetIncidentById(id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -61,24 +237,160 @@ func (s *IncidentService) UpdateIncident(id int, req models.IncidentRequest) (mo
 		return models.IncidentResponse{}, err
 	}
 
-	updateIncident, err := s.repo.UpdateIncident(id, req)
+	updateIncident, err := s.repo.UpdateIncident(id, req, meta)
 	if err != nil {
 		log.Println(err)
 		return models.IncidentResponse{}, err
 	}
 
+	s.invalidateActive(context.Background())
+
 	return updateIncident, nil
 }
 
-func (s *IncidentService) DeleteIncident(id int) error {
-	err := s.repo.DeleteIncident(id)
+// PatchIncident применяет merge-patch к текущему состоянию инцидента и
+// сохраняет результат как обычное изменение.
+func (s *IncidentService) PatchIncident(id int, patch models.IncidentPatch, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	if err := patch.Validate(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	current, err := s.repo.GetIncidentById(id)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	// патч наложен на прочитанную версию, поэтому сохраняем его только
+	// если инцидент с тех пор не изменился
+	if meta.IfVersion != 0 && meta.IfVersion != current.Version {
+		return models.IncidentResponse{}, models.ErrVersionConflict
+	}
+	meta.IfVersion = current.Version
+
+	req, err := patch.Apply(current)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if err := req.Validate(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if err := s.resolveType(context.Background(), &req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if err := s.checkBoundaries(context.Background(), req); err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	updated, err := s.repo.UpdateIncident(id, req, meta)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	s.invalidateActive(context.Background())
+
+	return updated, nil
+}
+
+func (s *IncidentService) DeleteIncident(id int, meta models.ChangeMeta) error {
+	err := s.repo.DeleteIncident(id, meta)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateActive(context.Background())
+
+	return nil
+}
+
+func (s *IncidentService) RestoreIncident(id int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	incident, err := s.repo.RestoreIncident(id, meta)
 	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	s.invalidateActive(context.Background())
+
+	return incident, nil
+}
+
+// PurgeIncident окончательно удаляет инцидент, если с момента удаления
+// прошёл срок хранения.
+func (s *IncidentService) PurgeIncident(ctx context.Context, id int) error {
+	if err := s.repo.PurgeIncident(ctx, id, s.purgeRetention); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// AddTrackPoints дополняет трек движущегося инцидента; версия инцидента при
+// этом не меняется.
+func (s *IncidentService) AddTrackPoints(ctx context.Context, id int, req models.TrackRequest, meta models.ChangeMeta) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddTrackPoints(ctx, id, req.Points, meta); err != nil {
+		log.Println(err)
 		return err
 	}
 
 	return nil
 }
 
+func (s *IncidentService) GetIncidentTrack(ctx context.Context, id int) ([]models.TrackPoint, error) {
+	return s.repo.GetIncidentTrack(ctx, id)
+}
+
+// MergeIncidents присоединяет дубль duplicateID к основному инциденту primaryID.
+func (s *IncidentService) MergeIncidents(primaryID, duplicateID int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	if primaryID == duplicateID {
+		return models.IncidentResponse{}, fmt.Errorf("%w: incident cannot be merged into itself", models.ErrValidation)
+	}
+
+	merged, err := s.repo.MergeIncidents(primaryID, duplicateID, meta)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	s.invalidateActive(context.Background())
+
+	return merged, nil
+}
+
+func (s *IncidentService) GetIncidentHistory(id int) ([]models.IncidentRevision, error) {
+	if _, err := s.repo.GetIncidentById(id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.GetIncidentHistory(id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetIncidentRevision возвращает состояние инцидента сразу после указанной ревизии.
+func (s *IncidentService) GetIncidentRevision(id, revision int) (models.IncidentResponse, error) {
+	rev, err := s.repo.GetIncidentRevision(id, revision)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+
+	var incident models.IncidentResponse
+	if err := json.Unmarshal(rev.After, &incident); err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	return incident, nil
+}
+
 func (s *IncidentService) GetIncidentStats(ctx context.Context) (models.IncidentStatsResponse, error) {
 
 	window := time.Duration(s.windowMin) * time.Minute
@@ -88,12 +400,69 @@ func (s *IncidentService) GetIncidentStats(ctx context.Context) (models.Incident
 		return models.IncidentStatsResponse{}, err
 	}
 
+	byType, err := s.types.GetIncidentTypeStats(ctx)
+	if err != nil {
+		return models.IncidentStatsResponse{}, err
+	}
+
 	return models.IncidentStatsResponse{
 		UserCount:    count,
 		WindowMinute: s.windowMin,
+		ByType:       byType,
 	}, nil
 }
 
+// resolveType проверяет, что тип есть в справочнике, и подставляет его
+// значения по умолчанию.
+func (s *IncidentService) resolveType(ctx context.Context, req *models.IncidentRequest) error {
+	t, err := s.types.GetIncidentType(ctx, req.Type)
+	if errors.Is(err, sql.ErrNoRows) {
+		return unknownTypeError(req.Type)
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return applyTypeDefaults(req, t)
+}
+
+// checkBoundaries проверяет, что границы зоны инцидента есть в справочнике.
+func (s *IncidentService) checkBoundaries(ctx context.Context, req models.IncidentRequest) error {
+	if len(req.BoundaryIDs) == 0 {
+		return nil
+	}
+
+	missing, err := s.boundaries.MissingBoundaries(ctx, req.BoundaryIDs)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: unknown boundary %d", models.ErrValidation, missing[0])
+	}
+
+	return nil
+}
+
+// applyTypeDefaults подставляет важность и радиус по умолчанию для типа t.
+func applyTypeDefaults(req *models.IncidentRequest, t models.IncidentType) error {
+	if req.Severity == "" {
+		req.Severity = t.DefaultSeverity
+	}
+	if req.NeedsRadius() {
+		if t.DefaultRadiusMeters <= 0 {
+			return fmt.Errorf("%w: radius_meters is required, type %q has no default radius", models.ErrValidation, t.Code)
+		}
+		req.RadiusMeters = t.DefaultRadiusMeters
+	}
+	return nil
+}
+
+func unknownTypeError(code string) error {
+	return fmt.Errorf("%w: unknown incident type %q", models.ErrValidation, code)
+}
+
 func (s *IncidentService) GetActiveIncidents(ctx context.Context) ([]models.IncidentResponse, error) {
 	if cached, err := s.cache.GetActive(ctx); err == nil && cached != nil {
 		return cached, nil
@@ -104,7 +473,88 @@ func (s *IncidentService) GetActiveIncidents(ctx context.Context) ([]models.Inci
 		return nil, err
 	}
 
-	_ = s.cache.SetActive(ctx, incidents, 30*time.Second)
+	_ = s.cache.SetActive(ctx, incidents, activeTTL(incidents, time.Now()))
 
 	return incidents, nil
 }
+
+// ApplySchedule запускает инциденты, чьё окно действия или повторение
+// началось, выключает истёкшие и отправляет события incident_started и
+// incident_ended. Для повторяющихся инцидентов события идут по каждому
+// повторению, поэтому закончившиеся повторения сменяются следующими до запуска.
+func (s *IncidentService) ApplySchedule(ctx context.Context) error {
+	finished, err := s.repo.AdvanceOccurrences(ctx)
+	if err != nil {
+		return err
+	}
+
+	started, err := s.repo.StartScheduledIncidents(ctx)
+	if err != nil {
+		return err
+	}
+
+	ended, err := s.repo.EndExpiredIncidents(ctx)
+	if err != nil {
+		return err
+	}
+	ended = append(finished, ended...)
+
+	if len(started) == 0 && len(ended) == 0 {
+		return nil
+	}
+
+	s.invalidateActive(ctx)
+
+	now := time.Now().UTC()
+	for _, inc := range started {
+		s.enqueueIncidentEvent(ctx, models.EventIncidentStarted, inc, now)
+	}
+	for _, inc := range ended {
+		s.enqueueIncidentEvent(ctx, models.EventIncidentEnded, inc, now)
+	}
+
+	return nil
+}
+
+func (s *IncidentService) enqueueIncidentEvent(ctx context.Context, event string, inc models.IncidentEvent, at time.Time) {
+	job := models.WebhookPayload{
+		Event:     event,
+		Lat:       inc.Latitude,
+		Lon:       inc.Longitude,
+		Incident:  &inc,
+		CheckedAt: at,
+	}
+
+	if err := s.webhookQueue.Enqueue(ctx, job); err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *IncidentService) invalidateActive(ctx context.Context) {
+	if err := s.cache.InvalidateActive(ctx); err != nil {
+		log.Println(err)
+	}
+}
+
+// activeTTL не даёт кэшу пережить ближайшее окончание действия инцидента.
+func activeTTL(incidents []models.IncidentResponse, now time.Time) time.Duration {
+	ttl := activeIncidentsTTL
+	for _, inc := range incidents {
+		endsAt := inc.EndsAt
+		if inc.OccurrenceEndsAt != nil {
+			endsAt = inc.OccurrenceEndsAt
+		}
+		if endsAt == nil {
+			continue
+		}
+		if left := endsAt.Sub(now); left < ttl {
+			ttl = left
+		}
+	}
+
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	return ttl
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// GetIncidentOccurrences возвращает до limit повторений, не закончившихся к
+// from, включая отменённые.
+func (s *IncidentService) GetIncidentOccurrences(ctx context.Context, id int, from time.Time, limit int) ([]models.Occurrence, error) {
+	incident, err := s.recurringIncident(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.repo.GetCancelledOccurrences(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []models.Occurrence{}
+	err = incident.Recurrence.Each(*incident.StartsAt, incident.EndsAt, from, func(o models.Occurrence) bool {
+		for _, c := range cancelled {
+			if c.Equal(o.StartsAt) {
+				o.Cancelled = true
+			}
+		}
+		occurrences = append(occurrences, o)
+		return len(occurrences) < limit
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return occurrences, nil
+}
+
+// CancelOccurrence отменяет одно повторение серии; start должен совпадать
+// с началом одного из повторений.
+func (s *IncidentService) CancelOccurrence(ctx context.Context, id int, start time.Time, meta models.ChangeMeta) error {
+	incident, err := s.recurringIncident(id)
+	if err != nil {
+		return err
+	}
+
+	if !incident.Recurrence.IsOccurrence(*incident.StartsAt, incident.EndsAt, start) {
+		return fmt.Errorf("%w: no occurrence starts at %s", models.ErrValidation, start.Format(time.RFC3339))
+	}
+
+	if err := s.repo.CancelOccurrence(ctx, id, start, meta); err != nil {
+		return err
+	}
+
+	s.invalidateActive(ctx)
+
+	return nil
+}
+
+func (s *IncidentService) recurringIncident(id int) (models.IncidentResponse, error) {
+	incident, err := s.repo.GetIncidentById(id)
+	if err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if incident.DeletedAt != nil {
+		return models.IncidentResponse{}, sql.ErrNoRows
+	}
+	if incident.Recurrence == nil || incident.StartsAt == nil {
+		return models.IncidentResponse{}, models.ErrNotRecurring
+	}
+
+	return incident, nil
+}
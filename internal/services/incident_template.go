@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+)
+
+type incidentTemplateService struct {
+	repo repository.IncidentTemplate
+}
+
+func NewIncidentTemplateService(repo repository.IncidentTemplate) *incidentTemplateService {
+	return &incidentTemplateService{repo: repo}
+}
+
+func (s *incidentTemplateService) ListIncidentTemplates(ctx context.Context) ([]models.IncidentTemplate, error) {
+	return s.repo.ListIncidentTemplates(ctx)
+}
+
+func (s *incidentTemplateService) GetIncidentTemplate(ctx context.Context, id int64) (models.IncidentTemplate, error) {
+	return s.repo.GetIncidentTemplate(ctx, id)
+}
+
+func (s *incidentTemplateService) CreateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	if err := t.Validate(); err != nil {
+		return models.IncidentTemplate{}, err
+	}
+
+	created, err := s.repo.CreateIncidentTemplate(ctx, t)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentTemplate{}, err
+	}
+
+	return created, nil
+}
+
+func (s *incidentTemplateService) UpdateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	if err := t.Validate(); err != nil {
+		return models.IncidentTemplate{}, err
+	}
+
+	updated, err := s.repo.UpdateIncidentTemplate(ctx, t)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentTemplate{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *incidentTemplateService) DeleteIncidentTemplate(ctx context.Context, id int64) error {
+	return s.repo.DeleteIncidentTemplate(ctx, id)
+}
+
+// ExpandTemplate собирает запрос на создание инцидента из шаблона ref и тела
+// запроса body: переданные в теле поля переопределяют поля шаблона, затем в
+// описание подставляются значения ref.Placeholders. Радиус без колец в теле
+// отменяет кольца шаблона.
+func (s *incidentTemplateService) ExpandTemplate(ctx context.Context, ref models.TemplateRef, body []byte) (models.IncidentRequest, error) {
+	t, err := s.repo.GetIncidentTemplate(ctx, ref.TemplateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IncidentRequest{}, fmt.Errorf("%w: unknown incident template %d", models.ErrValidation, ref.TemplateID)
+	}
+	if err != nil {
+		log.Println(err)
+		return models.IncidentRequest{}, err
+	}
+
+	var overrides map[string]json.RawMessage
+	if err := json.Unmarshal(body, &overrides); err != nil {
+		return models.IncidentRequest{}, fmt.Errorf("%w: %v", models.ErrValidation, err)
+	}
+
+	req := t.Request()
+	if err := json.Unmarshal(body, &req); err != nil {
+		return models.IncidentRequest{}, fmt.Errorf("%w: %v", models.ErrValidation, err)
+	}
+	if _, ok := overrides["radius_meters"]; ok {
+		if _, ok := overrides["tiers"]; !ok {
+			req.Tiers = nil
+		}
+	}
+
+	if req.Description, err = models.RenderDescription(req.Description, ref.Placeholders); err != nil {
+		return models.IncidentRequest{}, err
+	}
+
+	return req, nil
+}
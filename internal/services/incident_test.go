@@ -0,0 +1,109 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubIncidentRepo отдаёт заранее заданный инцидент без обращения к базе.
+type stubIncidentRepo struct {
+	repository.Incident
+	incident models.IncidentResponse
+}
+
+func (r stubIncidentRepo) GetIncidentById(int) (models.IncidentResponse, error) {
+	return r.incident, nil
+}
+
+func TestApplyTypeDefaults(t *testing.T) {
+	fire := models.IncidentType{Code: "fire", DefaultSeverity: models.SeverityDanger, DefaultRadiusMeters: 300}
+
+	testTable := []struct {
+		name        string
+		req         models.IncidentRequest
+		incType     models.IncidentType
+		expectedReq models.IncidentRequest
+		expectedErr bool
+	}{
+		{
+			name:        "Severity and radius from type",
+			req:         models.IncidentRequest{Type: "fire"},
+			incType:     fire,
+			expectedReq: models.IncidentRequest{Type: "fire", Severity: models.SeverityDanger, RadiusMeters: 300},
+		},
+		{
+			name:        "Explicit values kept",
+			req:         models.IncidentRequest{Type: "fire", Severity: models.SeverityInfo, RadiusMeters: 50},
+			incType:     fire,
+			expectedReq: models.IncidentRequest{Type: "fire", Severity: models.SeverityInfo, RadiusMeters: 50},
+		},
+		{
+			name:        "Radius from tiers",
+			req:         models.IncidentRequest{Type: "fire", Tiers: models.Tiers{{Level: models.TierDanger, RadiusMeters: 100}}},
+			incType:     fire,
+			expectedReq: models.IncidentRequest{Type: "fire", Severity: models.SeverityDanger, Tiers: models.Tiers{{Level: models.TierDanger, RadiusMeters: 100}}},
+		},
+		{
+			name:        "Polygon without radius",
+			req:         models.IncidentRequest{Type: "fire", Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[]}`)},
+			incType:     fire,
+			expectedReq: models.IncidentRequest{Type: "fire", Severity: models.SeverityDanger, Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[]}`)},
+		},
+		{
+			name:        "Type without default radius",
+			req:         models.IncidentRequest{Type: "flood"},
+			incType:     models.IncidentType{Code: "flood", DefaultSeverity: models.SeverityWarning},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := testCase.req
+			err := applyTypeDefaults(&req, testCase.incType)
+
+			if testCase.expectedErr {
+				assert.ErrorIs(t, err, models.ErrValidation)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedReq, req)
+		})
+	}
+}
+
+func TestPatchIncident_RadiusOnZone(t *testing.T) {
+	radius := models.IncidentPatch{RadiusMeters: models.Field[int]{Set: true, Value: 500}}
+
+	testTable := []struct {
+		name     string
+		incident models.IncidentResponse
+	}{
+		{
+			name:     "Polygon",
+			incident: models.IncidentResponse{Type: "fire", Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[]}`)},
+		},
+		{
+			name:     "Line",
+			incident: models.IncidentResponse{Type: "fire", Geometry: json.RawMessage(`{"type":"LineString","coordinates":[]}`), BufferMeters: 50},
+		},
+		{
+			name:     "Boundaries",
+			incident: models.IncidentResponse{Type: "fire", BoundaryIDs: models.BoundaryIDs{1}, Geometry: json.RawMessage(`{"type":"MultiPolygon","coordinates":[]}`)},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := &IncidentService{repo: stubIncidentRepo{incident: testCase.incident}}
+
+			_, err := s.PatchIncident(1, radius, models.ChangeMeta{})
+			assert.ErrorIs(t, err, models.ErrValidation)
+			assert.ErrorContains(t, err, "radius_meters is only allowed for radius-based incidents")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+)
+
+// TransitionIncident меняет состояние согласования инцидента от имени
+// оператора с ролью meta.Role. Комментарий согласующего попадает и в историю
+// изменений как причина.
+func (s *IncidentService) TransitionIncident(
+	ctx context.Context,
+	id int,
+	req models.TransitionRequest,
+	meta models.ChangeMeta,
+) (models.IncidentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return models.IncidentResponse{}, err
+	}
+	if req.Comment != "" {
+		meta.Reason = req.Comment
+	}
+
+	incident, err := s.repo.TransitionIncident(ctx, id, req, meta)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentResponse{}, err
+	}
+
+	// публикация и снятие с публикации меняют набор действующих инцидентов
+	s.invalidateActive(ctx)
+
+	return incident, nil
+}
+
+func (s *IncidentService) GetIncidentTransitions(ctx context.Context, id int) ([]models.IncidentTransition, error) {
+	if _, err := s.repo.GetIncidentById(id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetIncidentTransitions(ctx, id)
+}
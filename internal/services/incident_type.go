@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+)
+
+type incidentTypeService struct {
+	repo repository.IncidentType
+}
+
+func NewIncidentTypeService(repo repository.IncidentType) *incidentTypeService {
+	return &incidentTypeService{repo: repo}
+}
+
+func (s *incidentTypeService) ListIncidentTypes(ctx context.Context) ([]models.IncidentType, error) {
+	return s.repo.ListIncidentTypes(ctx)
+}
+
+func (s *incidentTypeService) GetIncidentType(ctx context.Context, code string) (models.IncidentType, error) {
+	return s.repo.GetIncidentType(ctx, code)
+}
+
+func (s *incidentTypeService) CreateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	if err := t.Validate(); err != nil {
+		return models.IncidentType{}, err
+	}
+	if err := t.ValidateCode(); err != nil {
+		return models.IncidentType{}, err
+	}
+
+	created, err := s.repo.CreateIncidentType(ctx, t)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentType{}, err
+	}
+
+	return created, nil
+}
+
+func (s *incidentTypeService) UpdateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	if err := t.Validate(); err != nil {
+		return models.IncidentType{}, err
+	}
+
+	updated, err := s.repo.UpdateIncidentType(ctx, t)
+	if err != nil {
+		log.Println(err)
+		return models.IncidentType{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *incidentTypeService) DeleteIncidentType(ctx context.Context, code string) error {
+	return s.repo.DeleteIncidentType(ctx, code)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubIncidentTypeRepo сохраняет тип без обращения к базе.
+type stubIncidentTypeRepo struct {
+	repository.IncidentType
+}
+
+func (stubIncidentTypeRepo) CreateIncidentType(_ context.Context, t models.IncidentType) (models.IncidentType, error) {
+	return t, nil
+}
+
+func (stubIncidentTypeRepo) UpdateIncidentType(_ context.Context, t models.IncidentType) (models.IncidentType, error) {
+	return t, nil
+}
+
+func TestIncidentTypeService_Code(t *testing.T) {
+	legacy := models.IncidentType{Code: "пожар", Names: models.LocalizedNames{"ru": "Пожар"}}
+	s := NewIncidentTypeService(stubIncidentTypeRepo{})
+
+	_, err := s.CreateIncidentType(context.Background(), legacy)
+	assert.ErrorIs(t, err, models.ErrValidation)
+
+	updated, err := s.UpdateIncidentType(context.Background(), legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, updated)
+}
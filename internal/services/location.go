@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"github.com/rusinadaria/geo-notification-system/internal/config"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 	"github.com/rusinadaria/geo-notification-system/internal/repository"
 	"log"
@@ -10,40 +11,154 @@ import (
 
 type locationCheckService struct {
 	repo         repository.LocationCheck
+	throttle     repository.NotificationThrottle
+	policy       config.NotificationPolicy
+	trackHorizon time.Duration
 	webhookQueue WebhookQueue
 }
 
-func NewLocationCheckService(repo repository.LocationCheck, webhookQueue WebhookQueue) *locationCheckService {
-	return &locationCheckService{repo: repo, webhookQueue: webhookQueue}
+func NewLocationCheckService(
+	repo repository.LocationCheck,
+	throttle repository.NotificationThrottle,
+	policy config.NotificationPolicy,
+	trackHorizon time.Duration,
+	webhookQueue WebhookQueue,
+) *locationCheckService {
+	return &locationCheckService{
+		repo:         repo,
+		throttle:     throttle,
+		policy:       policy,
+		trackHorizon: trackHorizon,
+		webhookQueue: webhookQueue,
+	}
 }
 
 func (l *locationCheckService) CheckLocation(ctx context.Context, checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error) {
 
-	nearbyResp, err := l.repo.CheckLocation(checkReq)
+	nearbyResp, err := l.repo.CheckLocation(checkReq, l.trackHorizon)
 
 	if err != nil {
 		log.Println(err)
 		return models.LocationCheckResponse{}, err
 	}
 
-	err = l.repo.SaveCheck(checkReq.UserID, checkReq.Lat, checkReq.Lon, true)
+	incidentIDs := make([]int64, 0, len(nearbyResp.Incidents))
+	for _, inc := range nearbyResp.Incidents {
+		incidentIDs = append(incidentIDs, inc.ID)
+		if inc.Severity.Higher(nearbyResp.Severity) {
+			nearbyResp.Severity = inc.Severity
+		}
+		if inc.Tier.Inner(nearbyResp.Tier) {
+			nearbyResp.Tier = inc.Tier
+		}
+		// во внешних кольцах пользователь предупреждается, но не в опасности
+		if inc.Tier == models.TierDanger && l.policy.For(inc.Severity).Danger {
+			nearbyResp.Danger = true
+		}
+	}
+
+	err = l.repo.SaveCheck(checkReq.UserID, checkReq.Lat, checkReq.Lon, nearbyResp.Danger, incidentIDs)
 	if err != nil {
 		log.Println(err)
 		return models.LocationCheckResponse{}, err
 	}
 
-	if nearbyResp.Danger {
-		job := models.WebhookPayload{
-			Event:     "danger_detected",
-			UserID:    checkReq.UserID,
-			Lat:       checkReq.Lat,
-			Lon:       checkReq.Lon,
-			Incidents: nearbyResp.Incidents,
-			CheckedAt: time.Now().UTC(),
+	l.notify(ctx, checkReq, nearbyResp)
+
+	return nearbyResp, nil
+}
+
+// tierEvents — события вебхука для внешних колец зоны. Для кольца danger
+// событие выбирается политикой оповещений: danger_detected или incident_nearby.
+var tierEvents = map[models.TierLevel]string{
+	models.TierWarning: models.EventWarningTier,
+	models.TierInfo:    models.EventInfoTier,
+}
+
+// notify ставит вебхуки в очередь только по инцидентам, о которых политика велит
+// оповещать и о которых пользователь ещё не оповещён в пределах интервала повтора.
+// По каждому кольцу зоны уходит отдельное событие; переход во внутреннее кольцо
+// оповещается заново, не дожидаясь интервала повтора.
+func (l *locationCheckService) notify(ctx context.Context, checkReq models.LocationCheckRequest, resp models.LocationCheckResponse) {
+	byTier := map[models.TierLevel][]models.NearbyIncidentResponse{}
+
+	for _, inc := range resp.Incidents {
+		policy := l.policy.For(inc.Severity)
+		if !policy.Notify {
+			continue
 		}
 
-		_ = l.webhookQueue.Enqueue(ctx, job)
+		allowed, err := l.throttle.Allow(ctx, checkReq.UserID, inc.ID, inc.Tier, policy.Renotify)
+		if err != nil {
+			// лучше оповестить повторно, чем пропустить оповещение
+			log.Println(err)
+		} else if !allowed {
+			continue
+		}
+
+		byTier[inc.Tier] = append(byTier[inc.Tier], inc)
 	}
 
-	return nearbyResp, nil
+	for _, tier := range []models.TierLevel{models.TierDanger, models.TierWarning, models.TierInfo} {
+		if incidents := byTier[tier]; len(incidents) > 0 {
+			_ = l.webhookQueue.Enqueue(ctx, l.tierPayload(checkReq, tier, incidents))
+		}
+	}
+}
+
+func (l *locationCheckService) tierPayload(
+	checkReq models.LocationCheckRequest,
+	tier models.TierLevel,
+	incidents []models.NearbyIncidentResponse,
+) models.WebhookPayload {
+	var (
+		severity models.Severity
+		danger   bool
+		priority = models.PriorityLow
+	)
+
+	for _, inc := range incidents {
+		policy := l.policy.For(inc.Severity)
+		if inc.Severity.Higher(severity) {
+			severity = inc.Severity
+		}
+		if policy.Danger {
+			danger = true
+		}
+		if p := policyPriority(policy); priorityRank[p] > priorityRank[priority] {
+			priority = p
+		}
+	}
+
+	event, ok := tierEvents[tier]
+	if !ok {
+		event = models.EventIncidentNearby
+		if danger {
+			event = models.EventDangerDetected
+		}
+	}
+
+	return models.WebhookPayload{
+		Event:     event,
+		UserID:    checkReq.UserID,
+		Lat:       checkReq.Lat,
+		Lon:       checkReq.Lon,
+		Incidents: incidents,
+		Severity:  severity,
+		Priority:  priority,
+		CheckedAt: time.Now().UTC(),
+	}
+}
+
+var priorityRank = map[string]int{
+	models.PriorityLow:    1,
+	models.PriorityNormal: 2,
+	models.PriorityHigh:   3,
+}
+
+func policyPriority(policy config.SeverityPolicy) string {
+	if policy.Priority == "" {
+		return models.PriorityNormal
+	}
+	return policy.Priority
 }
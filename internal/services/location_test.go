@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/config"
+	"github.com/rusinadaria/geo-notification-system/internal/models"
+	"github.com/rusinadaria/geo-notification-system/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLocationRepo отдаёт заранее заданные инциденты и запоминает сохранённую проверку.
+type stubLocationRepo struct {
+	repository.LocationCheck
+	incidents   []models.NearbyIncidentResponse
+	savedDanger *bool
+}
+
+func (r *stubLocationRepo) CheckLocation(models.LocationCheckRequest, time.Duration) (models.LocationCheckResponse, error) {
+	return models.LocationCheckResponse{Incidents: r.incidents}, nil
+}
+
+func (r *stubLocationRepo) SaveCheck(_ int, _, _ float64, hasDanger bool, _ []int64) error {
+	r.savedDanger = &hasDanger
+	return nil
+}
+
+// stubThrottle повторяет поведение ограничителя в Redis: ключ пользователь,
+// инцидент, кольцо занимается на interval, нулевой интервал не ограничивает.
+type stubThrottle struct {
+	seen  map[string]bool
+	err   error
+	calls int
+}
+
+func (t *stubThrottle) Allow(_ context.Context, userID int, incidentID int64, tier models.TierLevel, interval time.Duration) (bool, error) {
+	t.calls++
+	if t.err != nil {
+		return false, t.err
+	}
+	if interval <= 0 {
+		return true, nil
+	}
+
+	key := fmt.Sprintf("%d:%d:%s", userID, incidentID, tier)
+	if t.seen[key] {
+		return false, nil
+	}
+	if t.seen == nil {
+		t.seen = map[string]bool{}
+	}
+	t.seen[key] = true
+	return true, nil
+}
+
+type stubWebhookQueue struct {
+	jobs []models.WebhookPayload
+}
+
+func (q *stubWebhookQueue) Enqueue(_ context.Context, job models.WebhookPayload) error {
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+// sentWebhook — значимая часть вебхука без времени проверки.
+type sentWebhook struct {
+	Event       string
+	Severity    models.Severity
+	Priority    string
+	IncidentIDs []int64
+}
+
+func sentWebhooks(jobs []models.WebhookPayload) []sentWebhook {
+	var sent []sentWebhook
+	for _, job := range jobs {
+		s := sentWebhook{Event: job.Event, Severity: job.Severity, Priority: job.Priority}
+		for _, inc := range job.Incidents {
+			s.IncidentIDs = append(s.IncidentIDs, inc.ID)
+		}
+		sent = append(sent, s)
+	}
+	return sent
+}
+
+func nearby(id int64, severity models.Severity, tier models.TierLevel) models.NearbyIncidentResponse {
+	return models.NearbyIncidentResponse{ID: id, Type: "fire", Severity: severity, Tier: tier}
+}
+
+func TestLocationCheckService_Danger(t *testing.T) {
+	warningIsDanger := config.DefaultNotificationPolicy()
+	warningIsDanger.Severities[models.SeverityWarning] = config.SeverityPolicy{Danger: true}
+
+	testTable := []struct {
+		name             string
+		policy           config.NotificationPolicy
+		incidents        []models.NearbyIncidentResponse
+		expectedDanger   bool
+		expectedSeverity models.Severity
+		expectedTier     models.TierLevel
+	}{
+		{
+			name:   "No incidents",
+			policy: config.DefaultNotificationPolicy(),
+		},
+		{
+			name:             "Danger severity in danger tier",
+			policy:           config.DefaultNotificationPolicy(),
+			incidents:        []models.NearbyIncidentResponse{nearby(1, models.SeverityDanger, models.TierDanger)},
+			expectedDanger:   true,
+			expectedSeverity: models.SeverityDanger,
+			expectedTier:     models.TierDanger,
+		},
+		{
+			name:             "Critical severity in outer tier",
+			policy:           config.DefaultNotificationPolicy(),
+			incidents:        []models.NearbyIncidentResponse{nearby(1, models.SeverityCritical, models.TierWarning)},
+			expectedSeverity: models.SeverityCritical,
+			expectedTier:     models.TierWarning,
+		},
+		{
+			name:             "Warning severity is not danger by default",
+			policy:           config.DefaultNotificationPolicy(),
+			incidents:        []models.NearbyIncidentResponse{nearby(1, models.SeverityWarning, models.TierDanger)},
+			expectedSeverity: models.SeverityWarning,
+			expectedTier:     models.TierDanger,
+		},
+		{
+			name:             "Policy marks warning as danger",
+			policy:           warningIsDanger,
+			incidents:        []models.NearbyIncidentResponse{nearby(1, models.SeverityWarning, models.TierDanger)},
+			expectedDanger:   true,
+			expectedSeverity: models.SeverityWarning,
+			expectedTier:     models.TierDanger,
+		},
+		{
+			name:   "Highest severity and innermost tier",
+			policy: config.DefaultNotificationPolicy(),
+			incidents: []models.NearbyIncidentResponse{
+				nearby(1, models.SeverityCritical, models.TierInfo),
+				nearby(2, models.SeverityWarning, models.TierWarning),
+			},
+			expectedSeverity: models.SeverityCritical,
+			expectedTier:     models.TierWarning,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &stubLocationRepo{incidents: testCase.incidents}
+			s := NewLocationCheckService(repo, &stubThrottle{}, testCase.policy, 0, &stubWebhookQueue{})
+
+			resp, err := s.CheckLocation(context.Background(), models.LocationCheckRequest{UserID: 1})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedDanger, resp.Danger)
+			assert.Equal(t, testCase.expectedSeverity, resp.Severity)
+			assert.Equal(t, testCase.expectedTier, resp.Tier)
+			require.NotNil(t, repo.savedDanger)
+			assert.Equal(t, testCase.expectedDanger, *repo.savedDanger)
+		})
+	}
+}
+
+func TestLocationCheckService_Notify(t *testing.T) {
+	withoutPriority := config.DefaultNotificationPolicy()
+	withoutPriority.Severities[models.SeverityDanger] = config.SeverityPolicy{Danger: true, Notify: true}
+
+	testTable := []struct {
+		name          string
+		policy        config.NotificationPolicy
+		throttle      *stubThrottle
+		incidents     []models.NearbyIncidentResponse
+		expectedCalls int
+		expectedSent  []sentWebhook
+	}{
+		{
+			name:      "Notify disabled",
+			policy:    config.DefaultNotificationPolicy(),
+			throttle:  &stubThrottle{},
+			incidents: []models.NearbyIncidentResponse{nearby(1, models.SeverityInfo, models.TierDanger)},
+		},
+		{
+			name:          "Danger detected",
+			policy:        config.DefaultNotificationPolicy(),
+			throttle:      &stubThrottle{},
+			incidents:     []models.NearbyIncidentResponse{nearby(1, models.SeverityDanger, models.TierDanger)},
+			expectedCalls: 1,
+			expectedSent: []sentWebhook{
+				{Event: models.EventDangerDetected, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{1}},
+			},
+		},
+		{
+			name:          "Nearby without danger",
+			policy:        config.DefaultNotificationPolicy(),
+			throttle:      &stubThrottle{},
+			incidents:     []models.NearbyIncidentResponse{nearby(1, models.SeverityWarning, models.TierDanger)},
+			expectedCalls: 1,
+			expectedSent: []sentWebhook{
+				{Event: models.EventIncidentNearby, Severity: models.SeverityWarning, Priority: models.PriorityLow, IncidentIDs: []int64{1}},
+			},
+		},
+		{
+			name:     "Event per tier from inner to outer",
+			policy:   config.DefaultNotificationPolicy(),
+			throttle: &stubThrottle{},
+			incidents: []models.NearbyIncidentResponse{
+				nearby(1, models.SeverityDanger, models.TierInfo),
+				nearby(2, models.SeverityDanger, models.TierWarning),
+				nearby(3, models.SeverityDanger, models.TierDanger),
+			},
+			expectedCalls: 3,
+			expectedSent: []sentWebhook{
+				{Event: models.EventDangerDetected, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{3}},
+				{Event: models.EventWarningTier, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{2}},
+				{Event: models.EventInfoTier, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{1}},
+			},
+		},
+		{
+			name:     "Highest priority in tier",
+			policy:   config.DefaultNotificationPolicy(),
+			throttle: &stubThrottle{},
+			incidents: []models.NearbyIncidentResponse{
+				nearby(1, models.SeverityWarning, models.TierDanger),
+				nearby(2, models.SeverityCritical, models.TierDanger),
+			},
+			expectedCalls: 2,
+			expectedSent: []sentWebhook{
+				{Event: models.EventDangerDetected, Severity: models.SeverityCritical, Priority: models.PriorityHigh, IncidentIDs: []int64{1, 2}},
+			},
+		},
+		{
+			name:          "Priority defaults to normal",
+			policy:        withoutPriority,
+			throttle:      &stubThrottle{},
+			incidents:     []models.NearbyIncidentResponse{nearby(1, models.SeverityDanger, models.TierDanger)},
+			expectedCalls: 1,
+			expectedSent: []sentWebhook{
+				{Event: models.EventDangerDetected, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{1}},
+			},
+		},
+		{
+			name:          "Throttled",
+			policy:        config.DefaultNotificationPolicy(),
+			throttle:      &stubThrottle{seen: map[string]bool{"7:1:danger": true}},
+			incidents:     []models.NearbyIncidentResponse{nearby(1, models.SeverityDanger, models.TierDanger)},
+			expectedCalls: 1,
+		},
+		{
+			name:          "Throttle failure still notifies",
+			policy:        config.DefaultNotificationPolicy(),
+			throttle:      &stubThrottle{err: errors.New("redis is down")},
+			incidents:     []models.NearbyIncidentResponse{nearby(1, models.SeverityDanger, models.TierDanger)},
+			expectedCalls: 1,
+			expectedSent: []sentWebhook{
+				{Event: models.EventDangerDetected, Severity: models.SeverityDanger, Priority: models.PriorityNormal, IncidentIDs: []int64{1}},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			queue := &stubWebhookQueue{}
+			repo := &stubLocationRepo{incidents: testCase.incidents}
+			s := NewLocationCheckService(repo, testCase.throttle, testCase.policy, 0, queue)
+
+			_, err := s.CheckLocation(context.Background(), models.LocationCheckRequest{UserID: 7, Lat: 55.75, Lon: 37.61})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedCalls, testCase.throttle.calls)
+			assert.Equal(t, testCase.expectedSent, sentWebhooks(queue.jobs))
+			for _, job := range queue.jobs {
+				assert.Equal(t, 7, job.UserID)
+				assert.False(t, job.CheckedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestLocationCheckService_Renotify(t *testing.T) {
+	testTable := []struct {
+		name         string
+		severity     models.Severity
+		tiers        []models.TierLevel
+		expectedSent []int
+	}{
+		{
+			name:         "Same tier within interval",
+			severity:     models.SeverityDanger,
+			tiers:        []models.TierLevel{models.TierWarning, models.TierWarning},
+			expectedSent: []int{1, 0},
+		},
+		{
+			name:         "Moved to inner tier",
+			severity:     models.SeverityDanger,
+			tiers:        []models.TierLevel{models.TierWarning, models.TierWarning, models.TierDanger},
+			expectedSent: []int{1, 0, 1},
+		},
+		{
+			name:         "Critical on every check",
+			severity:     models.SeverityCritical,
+			tiers:        []models.TierLevel{models.TierDanger, models.TierDanger},
+			expectedSent: []int{1, 1},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &stubLocationRepo{}
+			throttle := &stubThrottle{}
+			s := NewLocationCheckService(repo, throttle, config.DefaultNotificationPolicy(), 0, nil)
+
+			var sent []int
+			for _, tier := range testCase.tiers {
+				queue := &stubWebhookQueue{}
+				s.webhookQueue = queue
+				repo.incidents = []models.NearbyIncidentResponse{nearby(1, testCase.severity, tier)}
+
+				_, err := s.CheckLocation(context.Background(), models.LocationCheckRequest{UserID: 7})
+				require.NoError(t, err)
+				sent = append(sent, len(queue.jobs))
+			}
+
+			assert.Equal(t, testCase.expectedSent, sent)
+		})
+	}
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/rusinadaria/geo-notification-system/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,62 +42,90 @@ func (m *MockIncident) EXPECT() *MockIncidentMockRecorder {
 	return m.recorder
 }
 
-// CheckLocation mocks base method.
-func (m *MockIncident) CheckLocation(ctx context.Context, checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error) {
+// AddTrackPoints mocks base method.
+func (m *MockIncident) AddTrackPoints(ctx context.Context, id int, req models.TrackRequest, meta models.ChangeMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckLocation", ctx, checkReq)
-	ret0, _ := ret[0].(models.LocationCheckResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "AddTrackPoints", ctx, id, req, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CheckLocation indicates an expected call of CheckLocation.
-func (mr *MockIncidentMockRecorder) CheckLocation(ctx, checkReq any) *gomock.Call {
+// AddTrackPoints indicates an expected call of AddTrackPoints.
+func (mr *MockIncidentMockRecorder) AddTrackPoints(ctx, id, req, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLocation", reflect.TypeOf((*MockIncident)(nil).CheckLocation), ctx, checkReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrackPoints", reflect.TypeOf((*MockIncident)(nil).AddTrackPoints), ctx, id, req, meta)
 }
 
-// CreateIncident mocks base method.
-func (m *MockIncident) CreateIncident(incidentData models.IncidentRequest) error {
+// CancelOccurrence mocks base method.
+func (m *MockIncident) CancelOccurrence(ctx context.Context, id int, start time.Time, meta models.ChangeMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateIncident", incidentData)
+	ret := m.ctrl.Call(m, "CancelOccurrence", ctx, id, start, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CancelOccurrence indicates an expected call of CancelOccurrence.
+func (mr *MockIncidentMockRecorder) CancelOccurrence(ctx, id, start, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOccurrence", reflect.TypeOf((*MockIncident)(nil).CancelOccurrence), ctx, id, start, meta)
+}
+
+// CreateIncident mocks base method.
+func (m *MockIncident) CreateIncident(incidentData models.IncidentRequest, meta models.ChangeMeta, force bool) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncident", incidentData, meta, force)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateIncident indicates an expected call of CreateIncident.
-func (mr *MockIncidentMockRecorder) CreateIncident(incidentData any) *gomock.Call {
+func (mr *MockIncidentMockRecorder) CreateIncident(incidentData, meta, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncident", reflect.TypeOf((*MockIncident)(nil).CreateIncident), incidentData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncident", reflect.TypeOf((*MockIncident)(nil).CreateIncident), incidentData, meta, force)
 }
 
 // DeleteIncident mocks base method.
-func (m *MockIncident) DeleteIncident(id int) error {
+func (m *MockIncident) DeleteIncident(id int, meta models.ChangeMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteIncident", id)
+	ret := m.ctrl.Call(m, "DeleteIncident", id, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteIncident indicates an expected call of DeleteIncident.
-func (mr *MockIncidentMockRecorder) DeleteIncident(id any) *gomock.Call {
+func (mr *MockIncidentMockRecorder) DeleteIncident(id, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncident", reflect.TypeOf((*MockIncident)(nil).DeleteIncident), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncident", reflect.TypeOf((*MockIncident)(nil).DeleteIncident), id, meta)
+}
+
+// ExportIncidents mocks base method.
+func (m *MockIncident) ExportIncidents(ctx context.Context, filter models.IncidentFilter, fn func(models.IncidentResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportIncidents", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportIncidents indicates an expected call of ExportIncidents.
+func (mr *MockIncidentMockRecorder) ExportIncidents(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportIncidents", reflect.TypeOf((*MockIncident)(nil).ExportIncidents), ctx, filter, fn)
 }
 
 // GetAllIncidents mocks base method.
-func (m *MockIncident) GetAllIncidents(limit, offset int) ([]models.IncidentResponse, error) {
+func (m *MockIncident) GetAllIncidents(filter models.IncidentFilter) (models.IncidentPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllIncidents", limit, offset)
-	ret0, _ := ret[0].([]models.IncidentResponse)
+	ret := m.ctrl.Call(m, "GetAllIncidents", filter)
+	ret0, _ := ret[0].(models.IncidentPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllIncidents indicates an expected call of GetAllIncidents.
-func (mr *MockIncidentMockRecorder) GetAllIncidents(limit, offset any) *gomock.Call {
+func (mr *MockIncidentMockRecorder) GetAllIncidents(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllIncidents", reflect.TypeOf((*MockIncident)(nil).GetAllIncidents), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllIncidents", reflect.TypeOf((*MockIncident)(nil).GetAllIncidents), filter)
 }
 
 // GetIncidentById mocks base method.
@@ -114,6 +143,51 @@ func (mr *MockIncidentMockRecorder) GetIncidentById(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentById", reflect.TypeOf((*MockIncident)(nil).GetIncidentById), id)
 }
 
+// GetIncidentHistory mocks base method.
+func (m *MockIncident) GetIncidentHistory(id int) ([]models.IncidentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentHistory", id)
+	ret0, _ := ret[0].([]models.IncidentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentHistory indicates an expected call of GetIncidentHistory.
+func (mr *MockIncidentMockRecorder) GetIncidentHistory(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentHistory", reflect.TypeOf((*MockIncident)(nil).GetIncidentHistory), id)
+}
+
+// GetIncidentOccurrences mocks base method.
+func (m *MockIncident) GetIncidentOccurrences(ctx context.Context, id int, from time.Time, limit int) ([]models.Occurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentOccurrences", ctx, id, from, limit)
+	ret0, _ := ret[0].([]models.Occurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentOccurrences indicates an expected call of GetIncidentOccurrences.
+func (mr *MockIncidentMockRecorder) GetIncidentOccurrences(ctx, id, from, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentOccurrences", reflect.TypeOf((*MockIncident)(nil).GetIncidentOccurrences), ctx, id, from, limit)
+}
+
+// GetIncidentRevision mocks base method.
+func (m *MockIncident) GetIncidentRevision(id, revision int) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentRevision", id, revision)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentRevision indicates an expected call of GetIncidentRevision.
+func (mr *MockIncidentMockRecorder) GetIncidentRevision(id, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentRevision", reflect.TypeOf((*MockIncident)(nil).GetIncidentRevision), id, revision)
+}
+
 // GetIncidentStats mocks base method.
 func (m *MockIncident) GetIncidentStats(ctx context.Context) (models.IncidentStatsResponse, error) {
 	m.ctrl.T.Helper()
@@ -129,19 +203,591 @@ func (mr *MockIncidentMockRecorder) GetIncidentStats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentStats", reflect.TypeOf((*MockIncident)(nil).GetIncidentStats), ctx)
 }
 
+// GetIncidentTrack mocks base method.
+func (m *MockIncident) GetIncidentTrack(ctx context.Context, id int) ([]models.TrackPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentTrack", ctx, id)
+	ret0, _ := ret[0].([]models.TrackPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentTrack indicates an expected call of GetIncidentTrack.
+func (mr *MockIncidentMockRecorder) GetIncidentTrack(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentTrack", reflect.TypeOf((*MockIncident)(nil).GetIncidentTrack), ctx, id)
+}
+
+// GetIncidentTransitions mocks base method.
+func (m *MockIncident) GetIncidentTransitions(ctx context.Context, id int) ([]models.IncidentTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentTransitions", ctx, id)
+	ret0, _ := ret[0].([]models.IncidentTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentTransitions indicates an expected call of GetIncidentTransitions.
+func (mr *MockIncidentMockRecorder) GetIncidentTransitions(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentTransitions", reflect.TypeOf((*MockIncident)(nil).GetIncidentTransitions), ctx, id)
+}
+
+// ImportIncidents mocks base method.
+func (m *MockIncident) ImportIncidents(rows []models.ImportRow, mode string, meta models.ChangeMeta) (models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportIncidents", rows, mode, meta)
+	ret0, _ := ret[0].(models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportIncidents indicates an expected call of ImportIncidents.
+func (mr *MockIncidentMockRecorder) ImportIncidents(rows, mode, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportIncidents", reflect.TypeOf((*MockIncident)(nil).ImportIncidents), rows, mode, meta)
+}
+
+// MergeIncidents mocks base method.
+func (m *MockIncident) MergeIncidents(primaryID, duplicateID int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeIncidents", primaryID, duplicateID, meta)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeIncidents indicates an expected call of MergeIncidents.
+func (mr *MockIncidentMockRecorder) MergeIncidents(primaryID, duplicateID, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeIncidents", reflect.TypeOf((*MockIncident)(nil).MergeIncidents), primaryID, duplicateID, meta)
+}
+
+// PatchIncident mocks base method.
+func (m *MockIncident) PatchIncident(id int, patch models.IncidentPatch, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchIncident", id, patch, meta)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchIncident indicates an expected call of PatchIncident.
+func (mr *MockIncidentMockRecorder) PatchIncident(id, patch, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchIncident", reflect.TypeOf((*MockIncident)(nil).PatchIncident), id, patch, meta)
+}
+
+// PurgeIncident mocks base method.
+func (m *MockIncident) PurgeIncident(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIncident", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeIncident indicates an expected call of PurgeIncident.
+func (mr *MockIncidentMockRecorder) PurgeIncident(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIncident", reflect.TypeOf((*MockIncident)(nil).PurgeIncident), ctx, id)
+}
+
+// RestoreIncident mocks base method.
+func (m *MockIncident) RestoreIncident(id int, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreIncident", id, meta)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreIncident indicates an expected call of RestoreIncident.
+func (mr *MockIncidentMockRecorder) RestoreIncident(id, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreIncident", reflect.TypeOf((*MockIncident)(nil).RestoreIncident), id, meta)
+}
+
+// SearchIncidents mocks base method.
+func (m *MockIncident) SearchIncidents(ctx context.Context, text string, filter models.IncidentFilter) (models.IncidentSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIncidents", ctx, text, filter)
+	ret0, _ := ret[0].(models.IncidentSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchIncidents indicates an expected call of SearchIncidents.
+func (mr *MockIncidentMockRecorder) SearchIncidents(ctx, text, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIncidents", reflect.TypeOf((*MockIncident)(nil).SearchIncidents), ctx, text, filter)
+}
+
+// TransitionIncident mocks base method.
+func (m *MockIncident) TransitionIncident(ctx context.Context, id int, req models.TransitionRequest, meta models.ChangeMeta) (models.IncidentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionIncident", ctx, id, req, meta)
+	ret0, _ := ret[0].(models.IncidentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionIncident indicates an expected call of TransitionIncident.
+func (mr *MockIncidentMockRecorder) TransitionIncident(ctx, id, req, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionIncident", reflect.TypeOf((*MockIncident)(nil).TransitionIncident), ctx, id, req, meta)
+}
+
 // UpdateIncident mocks base method.
-func (m *MockIncident) UpdateIncident(id int, req models.IncidentRequest) (models.IncidentResponse, error) {
+func (m *MockIncident) UpdateIncident(id int, req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateIncident", id, req)
+	ret := m.ctrl.Call(m, "UpdateIncident", id, req, meta)
 	ret0, _ := ret[0].(models.IncidentResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateIncident indicates an expected call of UpdateIncident.
-func (mr *MockIncidentMockRecorder) UpdateIncident(id, req any) *gomock.Call {
+func (mr *MockIncidentMockRecorder) UpdateIncident(id, req, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncident", reflect.TypeOf((*MockIncident)(nil).UpdateIncident), id, req, meta)
+}
+
+// MockIncidentType is a mock of IncidentType interface.
+type MockIncidentType struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentTypeMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentTypeMockRecorder is the mock recorder for MockIncidentType.
+type MockIncidentTypeMockRecorder struct {
+	mock *MockIncidentType
+}
+
+// NewMockIncidentType creates a new mock instance.
+func NewMockIncidentType(ctrl *gomock.Controller) *MockIncidentType {
+	mock := &MockIncidentType{ctrl: ctrl}
+	mock.recorder = &MockIncidentTypeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentType) EXPECT() *MockIncidentTypeMockRecorder {
+	return m.recorder
+}
+
+// CreateIncidentType mocks base method.
+func (m *MockIncidentType) CreateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncidentType", ctx, t)
+	ret0, _ := ret[0].(models.IncidentType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncidentType indicates an expected call of CreateIncidentType.
+func (mr *MockIncidentTypeMockRecorder) CreateIncidentType(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncidentType", reflect.TypeOf((*MockIncidentType)(nil).CreateIncidentType), ctx, t)
+}
+
+// DeleteIncidentType mocks base method.
+func (m *MockIncidentType) DeleteIncidentType(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncidentType", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncidentType indicates an expected call of DeleteIncidentType.
+func (mr *MockIncidentTypeMockRecorder) DeleteIncidentType(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncidentType", reflect.TypeOf((*MockIncidentType)(nil).DeleteIncidentType), ctx, code)
+}
+
+// GetIncidentType mocks base method.
+func (m *MockIncidentType) GetIncidentType(ctx context.Context, code string) (models.IncidentType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentType", ctx, code)
+	ret0, _ := ret[0].(models.IncidentType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentType indicates an expected call of GetIncidentType.
+func (mr *MockIncidentTypeMockRecorder) GetIncidentType(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentType", reflect.TypeOf((*MockIncidentType)(nil).GetIncidentType), ctx, code)
+}
+
+// ListIncidentTypes mocks base method.
+func (m *MockIncidentType) ListIncidentTypes(ctx context.Context) ([]models.IncidentType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncidentTypes", ctx)
+	ret0, _ := ret[0].([]models.IncidentType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncidentTypes indicates an expected call of ListIncidentTypes.
+func (mr *MockIncidentTypeMockRecorder) ListIncidentTypes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidentTypes", reflect.TypeOf((*MockIncidentType)(nil).ListIncidentTypes), ctx)
+}
+
+// UpdateIncidentType mocks base method.
+func (m *MockIncidentType) UpdateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncidentType", ctx, t)
+	ret0, _ := ret[0].(models.IncidentType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncidentType indicates an expected call of UpdateIncidentType.
+func (mr *MockIncidentTypeMockRecorder) UpdateIncidentType(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncidentType", reflect.TypeOf((*MockIncidentType)(nil).UpdateIncidentType), ctx, t)
+}
+
+// MockIncidentTemplate is a mock of IncidentTemplate interface.
+type MockIncidentTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentTemplateMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentTemplateMockRecorder is the mock recorder for MockIncidentTemplate.
+type MockIncidentTemplateMockRecorder struct {
+	mock *MockIncidentTemplate
+}
+
+// NewMockIncidentTemplate creates a new mock instance.
+func NewMockIncidentTemplate(ctrl *gomock.Controller) *MockIncidentTemplate {
+	mock := &MockIncidentTemplate{ctrl: ctrl}
+	mock.recorder = &MockIncidentTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentTemplate) EXPECT() *MockIncidentTemplateMockRecorder {
+	return m.recorder
+}
+
+// CreateIncidentTemplate mocks base method.
+func (m *MockIncidentTemplate) CreateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncidentTemplate", ctx, t)
+	ret0, _ := ret[0].(models.IncidentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncidentTemplate indicates an expected call of CreateIncidentTemplate.
+func (mr *MockIncidentTemplateMockRecorder) CreateIncidentTemplate(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncidentTemplate", reflect.TypeOf((*MockIncidentTemplate)(nil).CreateIncidentTemplate), ctx, t)
+}
+
+// DeleteIncidentTemplate mocks base method.
+func (m *MockIncidentTemplate) DeleteIncidentTemplate(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncidentTemplate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncidentTemplate indicates an expected call of DeleteIncidentTemplate.
+func (mr *MockIncidentTemplateMockRecorder) DeleteIncidentTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncidentTemplate", reflect.TypeOf((*MockIncidentTemplate)(nil).DeleteIncidentTemplate), ctx, id)
+}
+
+// ExpandTemplate mocks base method.
+func (m *MockIncidentTemplate) ExpandTemplate(ctx context.Context, ref models.TemplateRef, body []byte) (models.IncidentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandTemplate", ctx, ref, body)
+	ret0, _ := ret[0].(models.IncidentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandTemplate indicates an expected call of ExpandTemplate.
+func (mr *MockIncidentTemplateMockRecorder) ExpandTemplate(ctx, ref, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandTemplate", reflect.TypeOf((*MockIncidentTemplate)(nil).ExpandTemplate), ctx, ref, body)
+}
+
+// GetIncidentTemplate mocks base method.
+func (m *MockIncidentTemplate) GetIncidentTemplate(ctx context.Context, id int64) (models.IncidentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentTemplate", ctx, id)
+	ret0, _ := ret[0].(models.IncidentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentTemplate indicates an expected call of GetIncidentTemplate.
+func (mr *MockIncidentTemplateMockRecorder) GetIncidentTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentTemplate", reflect.TypeOf((*MockIncidentTemplate)(nil).GetIncidentTemplate), ctx, id)
+}
+
+// ListIncidentTemplates mocks base method.
+func (m *MockIncidentTemplate) ListIncidentTemplates(ctx context.Context) ([]models.IncidentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncidentTemplates", ctx)
+	ret0, _ := ret[0].([]models.IncidentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncidentTemplates indicates an expected call of ListIncidentTemplates.
+func (mr *MockIncidentTemplateMockRecorder) ListIncidentTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidentTemplates", reflect.TypeOf((*MockIncidentTemplate)(nil).ListIncidentTemplates), ctx)
+}
+
+// UpdateIncidentTemplate mocks base method.
+func (m *MockIncidentTemplate) UpdateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncidentTemplate", ctx, t)
+	ret0, _ := ret[0].(models.IncidentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncidentTemplate indicates an expected call of UpdateIncidentTemplate.
+func (mr *MockIncidentTemplateMockRecorder) UpdateIncidentTemplate(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncidentTemplate", reflect.TypeOf((*MockIncidentTemplate)(nil).UpdateIncidentTemplate), ctx, t)
+}
+
+// MockBoundary is a mock of Boundary interface.
+type MockBoundary struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoundaryMockRecorder
+	isgomock struct{}
+}
+
+// MockBoundaryMockRecorder is the mock recorder for MockBoundary.
+type MockBoundaryMockRecorder struct {
+	mock *MockBoundary
+}
+
+// NewMockBoundary creates a new mock instance.
+func NewMockBoundary(ctrl *gomock.Controller) *MockBoundary {
+	mock := &MockBoundary{ctrl: ctrl}
+	mock.recorder = &MockBoundaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoundary) EXPECT() *MockBoundaryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBoundary mocks base method.
+func (m *MockBoundary) DeleteBoundary(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoundary", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoundary indicates an expected call of DeleteBoundary.
+func (mr *MockBoundaryMockRecorder) DeleteBoundary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoundary", reflect.TypeOf((*MockBoundary)(nil).DeleteBoundary), ctx, id)
+}
+
+// GetBoundary mocks base method.
+func (m *MockBoundary) GetBoundary(ctx context.Context, id int64) (models.Boundary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoundary", ctx, id)
+	ret0, _ := ret[0].(models.Boundary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoundary indicates an expected call of GetBoundary.
+func (mr *MockBoundaryMockRecorder) GetBoundary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoundary", reflect.TypeOf((*MockBoundary)(nil).GetBoundary), ctx, id)
+}
+
+// ImportBoundaries mocks base method.
+func (m *MockBoundary) ImportBoundaries(ctx context.Context, boundaries []models.BoundaryImport) (models.BoundaryImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBoundaries", ctx, boundaries)
+	ret0, _ := ret[0].(models.BoundaryImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBoundaries indicates an expected call of ImportBoundaries.
+func (mr *MockBoundaryMockRecorder) ImportBoundaries(ctx, boundaries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBoundaries", reflect.TypeOf((*MockBoundary)(nil).ImportBoundaries), ctx, boundaries)
+}
+
+// ListBoundaries mocks base method.
+func (m *MockBoundary) ListBoundaries(ctx context.Context, filter models.BoundaryFilter) ([]models.Boundary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBoundaries", ctx, filter)
+	ret0, _ := ret[0].([]models.Boundary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBoundaries indicates an expected call of ListBoundaries.
+func (mr *MockBoundaryMockRecorder) ListBoundaries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBoundaries", reflect.TypeOf((*MockBoundary)(nil).ListBoundaries), ctx, filter)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// AbortIdempotent mocks base method.
+func (m *MockIdempotency) AbortIdempotent(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortIdempotent", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortIdempotent indicates an expected call of AbortIdempotent.
+func (mr *MockIdempotencyMockRecorder) AbortIdempotent(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortIdempotent", reflect.TypeOf((*MockIdempotency)(nil).AbortIdempotent), ctx, key)
+}
+
+// BeginIdempotent mocks base method.
+func (m *MockIdempotency) BeginIdempotent(ctx context.Context, key, fingerprint string) (*models.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginIdempotent", ctx, key, fingerprint)
+	ret0, _ := ret[0].(*models.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginIdempotent indicates an expected call of BeginIdempotent.
+func (mr *MockIdempotencyMockRecorder) BeginIdempotent(ctx, key, fingerprint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginIdempotent", reflect.TypeOf((*MockIdempotency)(nil).BeginIdempotent), ctx, key, fingerprint)
+}
+
+// CompleteIdempotent mocks base method.
+func (m *MockIdempotency) CompleteIdempotent(ctx context.Context, key string, resp models.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotent", ctx, key, resp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotent indicates an expected call of CompleteIdempotent.
+func (mr *MockIdempotencyMockRecorder) CompleteIdempotent(ctx, key, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotent", reflect.TypeOf((*MockIdempotency)(nil).CompleteIdempotent), ctx, key, resp)
+}
+
+// MockIncidentScheduler is a mock of IncidentScheduler interface.
+type MockIncidentScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentSchedulerMockRecorder
+	isgomock struct{}
+}
+
+// MockIncidentSchedulerMockRecorder is the mock recorder for MockIncidentScheduler.
+type MockIncidentSchedulerMockRecorder struct {
+	mock *MockIncidentScheduler
+}
+
+// NewMockIncidentScheduler creates a new mock instance.
+func NewMockIncidentScheduler(ctrl *gomock.Controller) *MockIncidentScheduler {
+	mock := &MockIncidentScheduler{ctrl: ctrl}
+	mock.recorder = &MockIncidentSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentScheduler) EXPECT() *MockIncidentSchedulerMockRecorder {
+	return m.recorder
+}
+
+// ApplySchedule mocks base method.
+func (m *MockIncidentScheduler) ApplySchedule(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySchedule", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySchedule indicates an expected call of ApplySchedule.
+func (mr *MockIncidentSchedulerMockRecorder) ApplySchedule(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySchedule", reflect.TypeOf((*MockIncidentScheduler)(nil).ApplySchedule), ctx)
+}
+
+// MockLocationService is a mock of LocationService interface.
+type MockLocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLocationServiceMockRecorder is the mock recorder for MockLocationService.
+type MockLocationServiceMockRecorder struct {
+	mock *MockLocationService
+}
+
+// NewMockLocationService creates a new mock instance.
+func NewMockLocationService(ctrl *gomock.Controller) *MockLocationService {
+	mock := &MockLocationService{ctrl: ctrl}
+	mock.recorder = &MockLocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationService) EXPECT() *MockLocationServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckLocation mocks base method.
+func (m *MockLocationService) CheckLocation(ctx context.Context, checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLocation", ctx, checkReq)
+	ret0, _ := ret[0].(models.LocationCheckResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLocation indicates an expected call of CheckLocation.
+func (mr *MockLocationServiceMockRecorder) CheckLocation(ctx, checkReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncident", reflect.TypeOf((*MockIncident)(nil).UpdateIncident), id, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLocation", reflect.TypeOf((*MockLocationService)(nil).CheckLocation), ctx, checkReq)
 }
 
 // MockHealthService is a mock of HealthService interface.
@@ -2,21 +2,72 @@ package services
 
 import (
 	"context"
+	"github.com/rusinadaria/geo-notification-system/internal/config"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
 	"github.com/rusinadaria/geo-notification-system/internal/repository"
+	"time"
 )
 
 //go:generate mockgen -destination=./mocks/mock.go -source=service.go -package=mocks
 
 type Incident interface {
-	CreateIncident(incidentData models.IncidentRequest) error
-	GetAllIncidents(limit, offset int) ([]models.IncidentResponse, error)
+	CreateIncident(incidentData models.IncidentRequest, meta models.ChangeMeta, force bool) (models.IncidentResponse, error)
+	ImportIncidents(rows []models.ImportRow, mode string, meta models.ChangeMeta) (models.ImportResult, error)
+	GetAllIncidents(filter models.IncidentFilter) (models.IncidentPage, error)
+	SearchIncidents(ctx context.Context, text string, filter models.IncidentFilter) (models.IncidentSearchResult, error)
 	GetIncidentById(id int) (models.IncidentResponse, error)
-	UpdateIncident(id int, req models.IncidentRequest) (models.IncidentResponse, error)
-	DeleteIncident(id int) error
+	ExportIncidents(ctx context.Context, filter models.IncidentFilter, fn func(inc models.IncidentResponse) error) error
+	UpdateIncident(id int, req models.IncidentRequest, meta models.ChangeMeta) (models.IncidentResponse, error)
+	PatchIncident(id int, patch models.IncidentPatch, meta models.ChangeMeta) (models.IncidentResponse, error)
+	DeleteIncident(id int, meta models.ChangeMeta) error
+	MergeIncidents(primaryID, duplicateID int, meta models.ChangeMeta) (models.IncidentResponse, error)
+	RestoreIncident(id int, meta models.ChangeMeta) (models.IncidentResponse, error)
+	PurgeIncident(ctx context.Context, id int) error
+	GetIncidentHistory(id int) ([]models.IncidentRevision, error)
+	AddTrackPoints(ctx context.Context, id int, req models.TrackRequest, meta models.ChangeMeta) error
+	GetIncidentTrack(ctx context.Context, id int) ([]models.TrackPoint, error)
+	GetIncidentOccurrences(ctx context.Context, id int, from time.Time, limit int) ([]models.Occurrence, error)
+	CancelOccurrence(ctx context.Context, id int, start time.Time, meta models.ChangeMeta) error
+	GetIncidentRevision(id, revision int) (models.IncidentResponse, error)
+	TransitionIncident(ctx context.Context, id int, req models.TransitionRequest, meta models.ChangeMeta) (models.IncidentResponse, error)
+	GetIncidentTransitions(ctx context.Context, id int) ([]models.IncidentTransition, error)
 	GetIncidentStats(ctx context.Context) (models.IncidentStatsResponse, error)
 }
 
+type IncidentType interface {
+	ListIncidentTypes(ctx context.Context) ([]models.IncidentType, error)
+	GetIncidentType(ctx context.Context, code string) (models.IncidentType, error)
+	CreateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error)
+	UpdateIncidentType(ctx context.Context, t models.IncidentType) (models.IncidentType, error)
+	DeleteIncidentType(ctx context.Context, code string) error
+}
+
+type IncidentTemplate interface {
+	ListIncidentTemplates(ctx context.Context) ([]models.IncidentTemplate, error)
+	GetIncidentTemplate(ctx context.Context, id int64) (models.IncidentTemplate, error)
+	CreateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error)
+	UpdateIncidentTemplate(ctx context.Context, t models.IncidentTemplate) (models.IncidentTemplate, error)
+	DeleteIncidentTemplate(ctx context.Context, id int64) error
+	ExpandTemplate(ctx context.Context, ref models.TemplateRef, body []byte) (models.IncidentRequest, error)
+}
+
+type Boundary interface {
+	ListBoundaries(ctx context.Context, filter models.BoundaryFilter) ([]models.Boundary, error)
+	GetBoundary(ctx context.Context, id int64) (models.Boundary, error)
+	ImportBoundaries(ctx context.Context, boundaries []models.BoundaryImport) (models.BoundaryImportResult, error)
+	DeleteBoundary(ctx context.Context, id int64) error
+}
+
+type Idempotency interface {
+	BeginIdempotent(ctx context.Context, key, fingerprint string) (*models.IdempotentResponse, error)
+	CompleteIdempotent(ctx context.Context, key string, resp models.IdempotentResponse) error
+	AbortIdempotent(ctx context.Context, key string) error
+}
+
+type IncidentScheduler interface {
+	ApplySchedule(ctx context.Context) error
+}
+
 type LocationService interface {
 	CheckLocation(ctx context.Context, checkReq models.LocationCheckRequest) (models.LocationCheckResponse, error)
 }
@@ -31,15 +82,42 @@ type WebhookQueue interface {
 
 type Service struct {
 	Incident
+	IncidentType
+	IncidentTemplate
+	Boundary
+	Idempotency
+	IncidentScheduler
 	HealthService
 	LocationService
 	WebhookQueue
 }
 
-func NewService(repos *repository.Repository, windowMin int, webhookQueue WebhookQueue) *Service {
+func NewService(repos *repository.Repository, cfg *config.Config, webhookQueue WebhookQueue) *Service {
+	incidentService := NewIncidentService(
+		repos.Incident,
+		repos.IncidentType,
+		repos.Boundary,
+		repos.IncidentCache,
+		cfg.WindowMin,
+		cfg.PurgeRetention,
+		DuplicateDetection{Window: cfg.DuplicateWindow, MinOverlap: cfg.DuplicateOverlap},
+		webhookQueue,
+	)
+
 	return &Service{
-		Incident:        NewIncidentService(repos.Incident, windowMin, webhookQueue),
-		HealthService:   NewHealthService(repos.DB, repos.Redis),
-		LocationService: NewLocationCheckService(repos.LocationCheck, webhookQueue),
+		Incident:          incidentService,
+		IncidentType:      NewIncidentTypeService(repos.IncidentType),
+		IncidentTemplate:  NewIncidentTemplateService(repos.IncidentTemplate),
+		Boundary:          NewBoundaryService(repos.Boundary),
+		Idempotency:       NewIdempotencyService(repos.IdempotencyStore, cfg.IdempotencyTTL),
+		IncidentScheduler: incidentService,
+		HealthService:     NewHealthService(repos.DB, repos.Redis),
+		LocationService: NewLocationCheckService(
+			repos.LocationCheck,
+			repos.NotificationThrottle,
+			cfg.NotificationPolicy,
+			cfg.TrackHorizon,
+			webhookQueue,
+		),
 	}
 }
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rusinadaria/geo-notification-system/internal/services"
+)
+
+// IncidentScheduler периодически применяет окна действия инцидентов:
+// включает наступившие и выключает истёкшие.
+type IncidentScheduler struct {
+	scheduler services.IncidentScheduler
+	interval  time.Duration
+}
+
+func NewIncidentScheduler(scheduler services.IncidentScheduler, interval time.Duration) *IncidentScheduler {
+	return &IncidentScheduler{
+		scheduler: scheduler,
+		interval:  interval,
+	}
+}
+
+func (s *IncidentScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.scheduler.ApplySchedule(ctx); err != nil {
+			log.Println("incident schedule error:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/rusinadaria/geo-notification-system/internal/models"
+	redisrepo "github.com/rusinadaria/geo-notification-system/internal/repository/redis"
 )
 
 type WebhookWorker struct {
@@ -30,7 +31,7 @@ func (w *WebhookWorker) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		default:
-			res, err := w.client.BRPop(ctx, 0, "webhooks:queue").Result()
+			res, err := w.client.BRPop(ctx, 0, redisrepo.WebhookQueueKeys...).Result()
 			if err != nil {
 				log.Println(err)
 				continue
@@ -76,7 +77,7 @@ func (w *WebhookWorker) send(job models.WebhookPayload) {
 func (w *WebhookWorker) retryLater(job models.WebhookPayload, delay time.Duration) {
 	time.Sleep(delay)
 	data, _ := json.Marshal(job)
-	if err := w.client.LPush(context.Background(), "webhooks:queue", data).Err(); err != nil {
+	if err := w.client.LPush(context.Background(), redisrepo.WebhookQueueKey(job.Priority), data).Err(); err != nil {
 		log.Println("failed to enqueue webhook retry:", err)
 	}
 }
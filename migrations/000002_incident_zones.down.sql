@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_incidents_zone;
+DROP INDEX IF EXISTS idx_incidents_location;
+
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_zone_or_radius;
+
+DELETE FROM incidents WHERE radius_meters IS NULL;
+ALTER TABLE incidents ALTER COLUMN radius_meters SET NOT NULL;
+
+ALTER TABLE incidents DROP COLUMN IF EXISTS zone;
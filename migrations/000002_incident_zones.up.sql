@@ -0,0 +1,11 @@
+-- Зона инцидента может быть задана полигоном/мультиполигоном.
+-- Для таких инцидентов location хранит центроид, а radius_meters пуст.
+ALTER TABLE incidents ADD COLUMN zone GEOGRAPHY(GEOMETRY, 4326);
+
+ALTER TABLE incidents ALTER COLUMN radius_meters DROP NOT NULL;
+
+ALTER TABLE incidents ADD CONSTRAINT incidents_zone_or_radius
+    CHECK (zone IS NOT NULL OR radius_meters IS NOT NULL);
+
+CREATE INDEX idx_incidents_location ON incidents USING GIST (location);
+CREATE INDEX idx_incidents_zone ON incidents USING GIST (zone);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_incidents_schedule_state;
+DROP INDEX IF EXISTS idx_incidents_active_time;
+
+ALTER TABLE incidents DROP COLUMN IF EXISTS schedule_state;
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_window_check;
+ALTER TABLE incidents DROP COLUMN IF EXISTS ends_at;
+ALTER TABLE incidents DROP COLUMN IF EXISTS starts_at;
@@ -0,0 +1,16 @@
+-- Окно действия инцидента. NULL означает «без ограничения» с соответствующей стороны.
+ALTER TABLE incidents ADD COLUMN starts_at TIMESTAMPTZ;
+ALTER TABLE incidents ADD COLUMN ends_at TIMESTAMPTZ;
+
+ALTER TABLE incidents ADD CONSTRAINT incidents_window_check
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at);
+
+-- Состояние, которое последним применил планировщик: pending -> started -> ended.
+ALTER TABLE incidents ADD COLUMN schedule_state VARCHAR(16) NOT NULL DEFAULT 'pending'
+    CHECK (schedule_state IN ('pending', 'started', 'ended'));
+
+-- Уже существующие инциденты не должны повторно рассылать событие о старте.
+UPDATE incidents SET schedule_state = CASE WHEN is_active THEN 'started' ELSE 'ended' END;
+
+CREATE INDEX idx_incidents_active_time ON incidents (is_active, starts_at, ends_at);
+CREATE INDEX idx_incidents_schedule_state ON incidents (schedule_state) WHERE schedule_state <> 'ended';
@@ -0,0 +1,3 @@
+-- Существующие инциденты получают уровень danger, чтобы оповещения по ним не изменились.
+ALTER TABLE incidents ADD COLUMN severity VARCHAR(16) NOT NULL DEFAULT 'danger'
+    CHECK (severity IN ('info', 'warning', 'danger', 'critical'));
@@ -0,0 +1,14 @@
+-- История изменений инцидентов. before/after — полные снимки инцидента
+-- в том виде, в каком его отдаёт API.
+CREATE TABLE incident_revisions (
+    id           BIGSERIAL PRIMARY KEY,
+    incident_id  BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    revision     INTEGER NOT NULL,
+    action       VARCHAR(16) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    operator     VARCHAR(100) NOT NULL,
+    reason       TEXT,
+    before       JSONB,
+    after        JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (incident_id, revision)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_incidents_updated_at;
+DROP INDEX IF EXISTS idx_incidents_created_at;
+DROP INDEX IF EXISTS idx_incidents_type;
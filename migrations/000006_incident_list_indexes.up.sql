@@ -0,0 +1,3 @@
+CREATE INDEX idx_incidents_type ON incidents (type);
+CREATE INDEX idx_incidents_created_at ON incidents (created_at DESC, id DESC);
+CREATE INDEX idx_incidents_updated_at ON incidents (updated_at DESC, id DESC);
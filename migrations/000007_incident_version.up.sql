@@ -0,0 +1,2 @@
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
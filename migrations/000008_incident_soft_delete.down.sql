@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS location_check_incidents;
+
+DELETE FROM incident_revisions WHERE action = 'restore';
+ALTER TABLE IF EXISTS incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE IF EXISTS incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete'));
+
+DROP INDEX IF EXISTS idx_incidents_deleted_at;
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
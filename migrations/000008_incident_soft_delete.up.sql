@@ -0,0 +1,34 @@
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(100);
+
+-- Ранее удалённые инциденты отличаются от выключенных только последней ревизией.
+UPDATE incidents i
+SET deleted_at = r.created_at,
+    deleted_by = r.operator
+FROM (
+    SELECT DISTINCT ON (incident_id) incident_id, action, operator, created_at
+    FROM incident_revisions
+    ORDER BY incident_id, revision DESC
+) r
+WHERE r.incident_id = i.id
+    AND r.action = 'delete'
+    AND NOT i.is_active
+    AND i.deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_deleted_at
+    ON incidents (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore'));
+
+-- Инциденты, попавшие в ответ на проверку локации.
+CREATE TABLE IF NOT EXISTS location_check_incidents (
+    check_id     BIGINT NOT NULL REFERENCES location_checks (id) ON DELETE CASCADE,
+    incident_id  BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    PRIMARY KEY (check_id, incident_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_check_incidents_incident
+    ON location_check_incidents (incident_id);
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS incidents DROP CONSTRAINT IF EXISTS incidents_type_fkey;
+
+DROP TABLE IF EXISTS incident_types;
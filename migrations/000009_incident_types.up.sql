@@ -0,0 +1,24 @@
+-- Справочник типов инцидентов. names — локализованные названия вида {"ru": "Пожар", "en": "Fire"}.
+CREATE TABLE IF NOT EXISTS incident_types (
+    code                   VARCHAR(50) PRIMARY KEY,
+    names                  JSONB NOT NULL DEFAULT '{}',
+    default_radius_meters  INTEGER CHECK (default_radius_meters > 0),
+    default_severity       VARCHAR(16) NOT NULL DEFAULT 'danger'
+        CHECK (default_severity IN ('info', 'warning', 'danger', 'critical')),
+    icon                   VARCHAR(100),
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Существующие типы приводятся к нижнему регистру и переносятся в справочник
+-- как есть; синонимы ("fire" и "пожар") объединяются вручную.
+UPDATE incidents SET type = lower(btrim(type)) WHERE type <> lower(btrim(type));
+
+INSERT INTO incident_types (code, names)
+SELECT DISTINCT type, jsonb_build_object('ru', type)
+FROM incidents
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE incidents DROP CONSTRAINT IF EXISTS incidents_type_fkey;
+ALTER TABLE incidents ADD CONSTRAINT incidents_type_fkey
+    FOREIGN KEY (type) REFERENCES incident_types (code) ON UPDATE CASCADE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_incidents_search_vector;
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,12 @@
+-- Полнотекстовый поиск по описанию: одни и те же слова индексируются с
+-- русской и английской морфологией, тип инцидента весит больше описания.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(type, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(type, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_search_vector
+    ON incidents USING GIN (search_vector);
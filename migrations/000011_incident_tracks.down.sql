@@ -0,0 +1,6 @@
+DROP FUNCTION IF EXISTS incident_position(BIGINT, TIMESTAMPTZ, INTERVAL);
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS tracked;
+
+DROP TABLE IF EXISTS incident_track_points;
@@ -0,0 +1,73 @@
+-- Трек движущегося инцидента. Точки с recorded_at в будущем — прогноз
+-- маршрута, между точками позиция интерполируется.
+CREATE TABLE IF NOT EXISTS incident_track_points (
+    id           BIGSERIAL PRIMARY KEY,
+    incident_id  BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    recorded_at  TIMESTAMPTZ NOT NULL,
+    location     GEOGRAPHY(POINT, 4326) NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (incident_id, recorded_at)
+);
+
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS tracked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- incident_position возвращает позицию инцидента на момент p_at: интерполяцию
+-- между соседними точками трека или, после последней точки, экстраполяцию по
+-- скорости между двумя последними точками не дальше чем на p_horizon.
+-- NULL — трек ещё не начался, инцидент находится в incidents.location.
+CREATE OR REPLACE FUNCTION incident_position(p_incident_id BIGINT, p_at TIMESTAMPTZ, p_horizon INTERVAL)
+RETURNS GEOGRAPHY
+LANGUAGE plpgsql STABLE AS $$
+DECLARE
+    p0 GEOGRAPHY; t0 TIMESTAMPTZ;
+    p1 GEOGRAPHY; t1 TIMESTAMPTZ;
+    p2 GEOGRAPHY; t2 TIMESTAMPTZ;
+    elapsed DOUBLE PRECISION;
+BEGIN
+    SELECT location, recorded_at INTO p1, t1
+    FROM incident_track_points
+    WHERE incident_id = p_incident_id AND recorded_at <= p_at
+    ORDER BY recorded_at DESC
+    LIMIT 1;
+
+    IF p1 IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    SELECT location, recorded_at INTO p2, t2
+    FROM incident_track_points
+    WHERE incident_id = p_incident_id AND recorded_at > p_at
+    ORDER BY recorded_at
+    LIMIT 1;
+
+    IF p2 IS NOT NULL THEN
+        RETURN ST_LineInterpolatePoint(
+            ST_MakeLine(p1::geometry, p2::geometry),
+            extract(epoch FROM p_at - t1) / extract(epoch FROM t2 - t1)
+        )::geography;
+    END IF;
+
+    IF p_horizon <= INTERVAL '0' OR p_at = t1 THEN
+        RETURN p1;
+    END IF;
+
+    SELECT location, recorded_at INTO p0, t0
+    FROM incident_track_points
+    WHERE incident_id = p_incident_id AND recorded_at < t1
+    ORDER BY recorded_at DESC
+    LIMIT 1;
+
+    IF p0 IS NULL OR ST_Equals(p0::geometry, p1::geometry) THEN
+        RETURN p1;
+    END IF;
+
+    elapsed := extract(epoch FROM LEAST(p_at - t1, p_horizon));
+
+    RETURN ST_Project(
+        p1,
+        ST_Distance(p0, p1) * elapsed / extract(epoch FROM t1 - t0),
+        ST_Azimuth(p0, p1)
+    );
+END;
+$$;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_incidents_type_created_at;
+
+DELETE FROM incident_revisions WHERE action = 'merge';
+ALTER TABLE IF EXISTS incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE IF EXISTS incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore'));
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS merged_into;
@@ -0,0 +1,10 @@
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS merged_into BIGINT REFERENCES incidents (id) ON DELETE SET NULL;
+
+ALTER TABLE incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge'));
+
+-- Поиск дублей смотрит на свежие инциденты того же типа.
+CREATE INDEX IF NOT EXISTS idx_incidents_type_created_at
+    ON incidents (type, created_at DESC) WHERE deleted_at IS NULL;
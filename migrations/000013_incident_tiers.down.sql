@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS tiers;
@@ -0,0 +1,5 @@
+-- Кольца зоны инцидента: [{"level": "danger", "radius_meters": 500}, ...].
+-- NULL — вся зона считается одним кольцом danger, как до появления колец.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS tiers JSONB
+        CHECK (tiers IS NULL OR jsonb_typeof(tiers) = 'array');
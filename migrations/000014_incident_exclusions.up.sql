@@ -0,0 +1,5 @@
+-- Безопасные области внутри зоны инцидента: [{"name": "...", "latitude": ...,
+-- "longitude": ..., "radius_meters": ...} | {"name": "...", "geometry": {...}}].
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS exclusions JSONB
+        CHECK (exclusions IS NULL OR jsonb_typeof(exclusions) = 'array');
@@ -0,0 +1,5 @@
+-- Линейные зоны (перекрытия дорог и путей): zone хранит LineString или
+-- MultiLineString, а зоной опасности считается буфер buffer_meters вокруг линии.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS buffer_meters INTEGER
+        CHECK (buffer_meters IS NULL OR buffer_meters > 0);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS incident_boundaries;
+DROP TABLE IF EXISTS boundaries;
@@ -0,0 +1,26 @@
+-- Административные границы: регион → город → район. path — идентификаторы
+-- предков от корня и самой границы, по нему ищутся вложенные границы.
+CREATE TABLE IF NOT EXISTS boundaries (
+    id          BIGSERIAL PRIMARY KEY,
+    parent_id   BIGINT REFERENCES boundaries (id),
+    level       VARCHAR(16) NOT NULL CHECK (level IN ('region', 'city', 'district')),
+    code        VARCHAR(64) NOT NULL UNIQUE,
+    name        VARCHAR(200) NOT NULL,
+    geom        GEOGRAPHY(MULTIPOLYGON, 4326) NOT NULL,
+    path        BIGINT[] NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_boundaries_geom ON boundaries USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_boundaries_path ON boundaries USING GIN (path);
+CREATE INDEX IF NOT EXISTS idx_boundaries_parent ON boundaries (parent_id);
+
+-- Границы, которыми задана зона инцидента; сама зона хранится в incidents.zone
+-- как объединение границ.
+CREATE TABLE IF NOT EXISTS incident_boundaries (
+    incident_id  BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    boundary_id  BIGINT NOT NULL REFERENCES boundaries (id),
+    PRIMARY KEY (incident_id, boundary_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_boundaries_boundary ON incident_boundaries (boundary_id);
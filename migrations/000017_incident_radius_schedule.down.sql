@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS incident_radius(JSONB, TIMESTAMPTZ, TIMESTAMPTZ);
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS radius_schedule;
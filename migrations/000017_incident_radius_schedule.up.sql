@@ -0,0 +1,40 @@
+-- Расписание радиуса: зона растёт или сжимается со временем (утечка газа
+-- распространяется, затем рассеивается). radius_meters хранит наибольший
+-- радиус расписания — огибающую зоны для фильтров и поиска дублей.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS radius_schedule JSONB;
+
+-- incident_radius возвращает радиус по расписанию на момент p_at: линейную
+-- интерполяцию между соседними шагами, отсчитывая offset_seconds от p_origin.
+-- После последнего шага радиус не меняется. NULL — расписания нет.
+CREATE OR REPLACE FUNCTION incident_radius(p_schedule JSONB, p_origin TIMESTAMPTZ, p_at TIMESTAMPTZ)
+RETURNS DOUBLE PRECISION
+LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    elapsed DOUBLE PRECISION := extract(epoch FROM p_at - p_origin);
+    prev_offset DOUBLE PRECISION;
+    prev_radius DOUBLE PRECISION;
+    step RECORD;
+BEGIN
+    IF p_schedule IS NULL OR jsonb_array_length(p_schedule) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    FOR step IN
+        SELECT (s->>'offset_seconds')::float8 AS off, (s->>'radius_meters')::float8 AS radius
+        FROM jsonb_array_elements(p_schedule) s
+        ORDER BY 1
+    LOOP
+        IF elapsed <= step.off THEN
+            IF prev_offset IS NULL THEN
+                RETURN step.radius;
+            END IF;
+            RETURN prev_radius + (step.radius - prev_radius) * (elapsed - prev_offset) / (step.off - prev_offset);
+        END IF;
+        prev_offset := step.off;
+        prev_radius := step.radius;
+    END LOOP;
+
+    RETURN prev_radius;
+END;
+$$;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS incident_occurrence_cancellations;
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,14 @@
+-- Повторяющиеся инциденты: recurrence хранит правило RRULE и длительность
+-- повторения, первое повторение начинается в starts_at.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS recurrence JSONB;
+
+-- Отменённые повторения серии; сама серия при отмене не меняется.
+CREATE TABLE IF NOT EXISTS incident_occurrence_cancellations (
+    incident_id       BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    occurrence_start  TIMESTAMPTZ NOT NULL,
+    cancelled_by      VARCHAR(100),
+    reason            TEXT,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (incident_id, occurrence_start)
+);
@@ -0,0 +1,13 @@
+-- Шаблоны инцидентов для быстрого ввода: описание с подстановками вида
+-- {{street}}, радиус, кольца и критичность по умолчанию.
+CREATE TABLE IF NOT EXISTS incident_templates (
+    id             BIGSERIAL PRIMARY KEY,
+    name           VARCHAR(100) NOT NULL UNIQUE,
+    type           VARCHAR(50) NOT NULL REFERENCES incident_types (code) ON UPDATE CASCADE,
+    severity       VARCHAR(16) CHECK (severity IN ('info', 'warning', 'danger', 'critical')),
+    description    TEXT NOT NULL DEFAULT '',
+    radius_meters  INTEGER CHECK (radius_meters > 0),
+    tiers          JSONB,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,11 @@
+DELETE FROM incident_revisions WHERE action = 'transition';
+ALTER TABLE IF EXISTS incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE IF EXISTS incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge'));
+
+DROP TABLE IF EXISTS incident_transitions;
+
+DROP INDEX IF EXISTS idx_incidents_status;
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS status;
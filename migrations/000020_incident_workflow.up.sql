@@ -0,0 +1,30 @@
+-- Согласование инцидентов: draft → pending_review → published → resolved → archived.
+-- Оповещения идут только по опубликованным инцидентам. Уже заведённые
+-- инциденты считаются опубликованными, новые начинают с черновика.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'pending_review', 'published', 'resolved', 'archived'));
+
+ALTER TABLE incidents ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_status
+    ON incidents (status) WHERE deleted_at IS NULL;
+
+-- Переходы между состояниями с комментарием согласующего.
+CREATE TABLE IF NOT EXISTS incident_transitions (
+    id           BIGSERIAL PRIMARY KEY,
+    incident_id  BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    from_status  VARCHAR(16) NOT NULL,
+    to_status    VARCHAR(16) NOT NULL,
+    operator     VARCHAR(100) NOT NULL,
+    role         VARCHAR(16) NOT NULL,
+    comment      TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_transitions_incident
+    ON incident_transitions (incident_id, id);
+
+ALTER TABLE incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'transition'));
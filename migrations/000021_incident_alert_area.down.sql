@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_incidents_tracked;
+DROP INDEX IF EXISTS idx_incidents_alert_area;
+
+DROP FUNCTION IF EXISTS incident_alert_area(GEOGRAPHY, GEOGRAPHY, DOUBLE PRECISION);
+DROP FUNCTION IF EXISTS incident_reach(INTEGER, INTEGER, JSONB);
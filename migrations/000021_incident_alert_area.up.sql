@@ -0,0 +1,36 @@
+-- incident_reach — на каком расстоянии от зоны (для круга — от центра)
+-- инцидент ещё может оповещать: внешнее кольцо, радиус круга (для круга с
+-- расписанием — наибольший радиус расписания) или ширина буфера линии.
+CREATE OR REPLACE FUNCTION incident_reach(p_radius INTEGER, p_buffer INTEGER, p_tiers JSONB)
+RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT GREATEST(
+        COALESCE(p_radius, 0),
+        COALESCE(p_buffer, 0),
+        COALESCE((
+            SELECT max((t->>'radius_meters')::float8)
+            FROM jsonb_array_elements(p_tiers) t
+        ), 0)
+    )
+$$;
+
+-- incident_alert_area — область, вне которой инцидент не оповещает. Буфер на
+-- сфере строится многоугольником, вписанным в окружность, поэтому радиус
+-- берётся с запасом: область нужна только для отбора кандидатов по индексу.
+CREATE OR REPLACE FUNCTION incident_alert_area(p_location GEOGRAPHY, p_zone GEOGRAPHY, p_reach DOUBLE PRECISION)
+RETURNS GEOGRAPHY
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE
+        WHEN p_reach > 0 THEN ST_Buffer(COALESCE(p_zone, p_location), p_reach * 1.01 + 1)
+        ELSE COALESCE(p_zone, p_location)
+    END
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_alert_area ON incidents USING GIST (
+    incident_alert_area(location, zone, incident_reach(radius_meters, buffer_meters, tiers))
+) WHERE deleted_at IS NULL;
+
+-- Позиция движущегося инцидента известна только на момент проверки, такие
+-- инциденты отбираются отдельно.
+CREATE INDEX IF NOT EXISTS idx_incidents_tracked
+    ON incidents (id) WHERE tracked AND deleted_at IS NULL;
@@ -0,0 +1,10 @@
+DELETE FROM incident_revisions WHERE action = 'cancel';
+ALTER TABLE IF EXISTS incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE IF EXISTS incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'transition'));
+
+DROP INDEX IF EXISTS idx_incidents_occurrence_due;
+
+ALTER TABLE IF EXISTS incidents
+    DROP COLUMN IF EXISTS occurrence_starts_at,
+    DROP COLUMN IF EXISTS occurrence_ends_at;
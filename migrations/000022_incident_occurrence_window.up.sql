@@ -0,0 +1,18 @@
+-- Текущее или ближайшее неотменённое повторение серии. Его продвигает
+-- планировщик, а live, список действующих и проверка точки читают его, не
+-- разворачивая правило для каждой строки. У исчерпанной серии окно пустое.
+ALTER TABLE incidents
+    ADD COLUMN IF NOT EXISTS occurrence_starts_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS occurrence_ends_at TIMESTAMPTZ;
+
+-- окна существующих серий заполнит первый проход планировщика
+UPDATE incidents SET schedule_state = 'pending'
+WHERE recurrence IS NOT NULL AND schedule_state <> 'ended';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_occurrence_due
+    ON incidents (occurrence_ends_at)
+    WHERE recurrence IS NOT NULL AND schedule_state <> 'ended';
+
+ALTER TABLE incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'transition', 'cancel'));
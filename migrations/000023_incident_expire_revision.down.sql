@@ -0,0 +1,4 @@
+DELETE FROM incident_revisions WHERE action = 'expire';
+ALTER TABLE IF EXISTS incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE IF EXISTS incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'transition', 'cancel'));
@@ -0,0 +1,10 @@
+-- Планировщик пишет ревизию, когда выключает инцидент с истёкшим окном действия.
+ALTER TABLE incident_revisions DROP CONSTRAINT IF EXISTS incident_revisions_action_check;
+ALTER TABLE incident_revisions ADD CONSTRAINT incident_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'transition', 'cancel', 'expire'));
+
+-- Опубликованные инциденты без окна действия уже оповещают и не должны
+-- рассылать событие о старте. Черновики получат его после публикации.
+UPDATE incidents SET schedule_state = 'started'
+WHERE schedule_state = 'pending' AND starts_at IS NULL AND ends_at IS NULL
+    AND status = 'published';